@@ -0,0 +1,686 @@
+package jira
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ADF node types
+const (
+	ADF_DOC          = "doc"
+	ADF_PARAGRAPH    = "paragraph"
+	ADF_HEADING      = "heading"
+	ADF_TEXT         = "text"
+	ADF_HARD_BREAK   = "hardBreak"
+	ADF_BULLET_LIST  = "bulletList"
+	ADF_ORDERED_LIST = "orderedList"
+	ADF_LIST_ITEM    = "listItem"
+	ADF_CODE_BLOCK   = "codeBlock"
+	ADF_BLOCKQUOTE   = "blockquote"
+	ADF_PANEL        = "panel"
+	ADF_RULE         = "rule"
+	ADF_TABLE        = "table"
+	ADF_TABLE_ROW    = "tableRow"
+	ADF_TABLE_HEADER = "tableHeader"
+	ADF_TABLE_CELL   = "tableCell"
+	ADF_MENTION      = "mention"
+	ADF_EMOJI        = "emoji"
+	ADF_INLINE_CARD  = "inlineCard"
+	ADF_STATUS       = "status"
+	ADF_MEDIA_SINGLE = "mediaSingle"
+	ADF_MEDIA        = "media"
+)
+
+// ADF mark types
+const (
+	ADF_MARK_STRONG    = "strong"
+	ADF_MARK_EM        = "em"
+	ADF_MARK_CODE      = "code"
+	ADF_MARK_STRIKE    = "strike"
+	ADF_MARK_UNDERLINE = "underline"
+	ADF_MARK_LINK      = "link"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ADFNode is Atlassian Document Format node
+// https://developer.atlassian.com/cloud/jira/platform/apis/document/structure/
+type ADFNode struct {
+	Attrs   map[string]any `json:"attrs,omitempty"`
+	Type    string         `json:"type"`
+	Text    string         `json:"text,omitempty"`
+	Content []*ADFNode     `json:"content,omitempty"`
+	Marks   []*ADFMark     `json:"marks,omitempty"`
+	Version int            `json:"version,omitempty"`
+}
+
+// ADFMark is Atlassian Document Format text mark
+type ADFMark struct {
+	Attrs map[string]any `json:"attrs,omitempty"`
+	Type  string         `json:"type"`
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewADF creates new ADF document
+func NewADF(content ...*ADFNode) *ADFNode {
+	return &ADFNode{Type: ADF_DOC, Version: 1, Content: content}
+}
+
+// ADFParagraph creates paragraph node
+func ADFParagraph(content ...*ADFNode) *ADFNode {
+	return &ADFNode{Type: ADF_PARAGRAPH, Content: content}
+}
+
+// ADFHeading creates heading node with given level (1-6)
+func ADFHeading(level int, content ...*ADFNode) *ADFNode {
+	return &ADFNode{
+		Type:    ADF_HEADING,
+		Attrs:   map[string]any{"level": min(max(level, 1), 6)},
+		Content: content,
+	}
+}
+
+// ADFText creates text node
+func ADFText(text string) *ADFNode {
+	return &ADFNode{Type: ADF_TEXT, Text: text}
+}
+
+// ADFHardBreak creates line break node
+func ADFHardBreak() *ADFNode {
+	return &ADFNode{Type: ADF_HARD_BREAK}
+}
+
+// ADFBulletList creates bullet list node
+func ADFBulletList(items ...*ADFNode) *ADFNode {
+	return &ADFNode{Type: ADF_BULLET_LIST, Content: items}
+}
+
+// ADFOrderedList creates ordered list node
+func ADFOrderedList(items ...*ADFNode) *ADFNode {
+	return &ADFNode{Type: ADF_ORDERED_LIST, Content: items}
+}
+
+// ADFListItem creates list item node
+func ADFListItem(content ...*ADFNode) *ADFNode {
+	return &ADFNode{Type: ADF_LIST_ITEM, Content: content}
+}
+
+// ADFCodeBlock creates code block node
+func ADFCodeBlock(language, code string) *ADFNode {
+	node := &ADFNode{Type: ADF_CODE_BLOCK}
+
+	if language != "" {
+		node.Attrs = map[string]any{"language": language}
+	}
+
+	if code != "" {
+		node.Content = []*ADFNode{ADFText(code)}
+	}
+
+	return node
+}
+
+// ADFBlockquote creates quote node
+func ADFBlockquote(content ...*ADFNode) *ADFNode {
+	return &ADFNode{Type: ADF_BLOCKQUOTE, Content: content}
+}
+
+// ADFRule creates horizontal rule node
+func ADFRule() *ADFNode {
+	return &ADFNode{Type: ADF_RULE}
+}
+
+// ADFTable creates table node
+func ADFTable(rows ...*ADFNode) *ADFNode {
+	return &ADFNode{Type: ADF_TABLE, Content: rows}
+}
+
+// ADFTableRow creates table row node
+func ADFTableRow(cells ...*ADFNode) *ADFNode {
+	return &ADFNode{Type: ADF_TABLE_ROW, Content: cells}
+}
+
+// ADFTableHeader creates table header cell node
+func ADFTableHeader(content ...*ADFNode) *ADFNode {
+	return &ADFNode{Type: ADF_TABLE_HEADER, Content: content}
+}
+
+// ADFTableCell creates table cell node
+func ADFTableCell(content ...*ADFNode) *ADFNode {
+	return &ADFNode{Type: ADF_TABLE_CELL, Content: content}
+}
+
+// ADFMention creates user mention node
+func ADFMention(accountID, name string) *ADFNode {
+	return &ADFNode{
+		Type:  ADF_MENTION,
+		Attrs: map[string]any{"id": accountID, "text": "@" + strings.TrimPrefix(name, "@")},
+	}
+}
+
+// ADFImage creates node with external image
+func ADFImage(url string) *ADFNode {
+	return &ADFNode{
+		Type: ADF_MEDIA_SINGLE,
+		Content: []*ADFNode{{
+			Type:  ADF_MEDIA,
+			Attrs: map[string]any{"type": "external", "url": url},
+		}},
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Append appends child nodes to node
+func (n *ADFNode) Append(content ...*ADFNode) *ADFNode {
+	n.Content = append(n.Content, content...)
+	return n
+}
+
+// Bold adds strong mark to text node
+func (n *ADFNode) Bold() *ADFNode {
+	return n.addMark(&ADFMark{Type: ADF_MARK_STRONG})
+}
+
+// Italic adds emphasis mark to text node
+func (n *ADFNode) Italic() *ADFNode {
+	return n.addMark(&ADFMark{Type: ADF_MARK_EM})
+}
+
+// Code adds code mark to text node
+func (n *ADFNode) Code() *ADFNode {
+	return n.addMark(&ADFMark{Type: ADF_MARK_CODE})
+}
+
+// Strike adds strikethrough mark to text node
+func (n *ADFNode) Strike() *ADFNode {
+	return n.addMark(&ADFMark{Type: ADF_MARK_STRIKE})
+}
+
+// Underline adds underline mark to text node
+func (n *ADFNode) Underline() *ADFNode {
+	return n.addMark(&ADFMark{Type: ADF_MARK_UNDERLINE})
+}
+
+// Link adds link mark to text node
+func (n *ADFNode) Link(url string) *ADFNode {
+	return n.addMark(&ADFMark{Type: ADF_MARK_LINK, Attrs: map[string]any{"href": url}})
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// PlainText converts ADF node to plain text
+func (n *ADFNode) PlainText() string {
+	if n == nil {
+		return ""
+	}
+
+	return strings.TrimRight(renderPlainText(n), "\n")
+}
+
+// Markdown converts ADF node to Markdown
+func (n *ADFNode) Markdown() string {
+	if n == nil {
+		return ""
+	}
+
+	return strings.TrimRight(renderMarkdown(n), "\n")
+}
+
+// HTML converts ADF node to HTML
+func (n *ADFNode) HTML() string {
+	if n == nil {
+		return ""
+	}
+
+	return renderHTML(n)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// addMark adds mark to node
+func (n *ADFNode) addMark(mark *ADFMark) *ADFNode {
+	n.Marks = append(n.Marks, mark)
+	return n
+}
+
+// attr returns node attribute as a string
+func (n *ADFNode) attr(name string) string {
+	value, ok := n.Attrs[name]
+
+	if !ok || value == nil {
+		return ""
+	}
+
+	return fmt.Sprint(value)
+}
+
+// intAttr returns node attribute as an integer
+func (n *ADFNode) intAttr(name string, defValue int) int {
+	value, err := strconv.Atoi(n.attr(name))
+
+	if err != nil {
+		return defValue
+	}
+
+	return value
+}
+
+// isInline returns true if node is inline node
+func (n *ADFNode) isInline() bool {
+	switch n.Type {
+	case ADF_TEXT, ADF_HARD_BREAK, ADF_MENTION, ADF_EMOJI, ADF_INLINE_CARD,
+		ADF_STATUS, ADF_MEDIA:
+		return true
+	}
+
+	return false
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// renderPlainText renders node as plain text
+func renderPlainText(n *ADFNode) string {
+	switch n.Type {
+	case ADF_TEXT:
+		return n.Text
+	case ADF_HARD_BREAK:
+		return "\n"
+	case ADF_MENTION, ADF_EMOJI, ADF_STATUS:
+		return getADFInlineText(n)
+	case ADF_INLINE_CARD, ADF_MEDIA:
+		return n.attr("url")
+	case ADF_RULE:
+		return ""
+	case ADF_TABLE_ROW:
+		return renderPlainTextNodes(n.Content, "\t")
+	case ADF_PARAGRAPH, ADF_HEADING, ADF_CODE_BLOCK, ADF_TABLE_HEADER, ADF_TABLE_CELL:
+		return renderPlainTextNodes(n.Content, "")
+	case ADF_BULLET_LIST, ADF_ORDERED_LIST, ADF_LIST_ITEM, ADF_TABLE:
+		return renderPlainTextNodes(n.Content, "\n")
+	}
+
+	return renderPlainTextNodes(n.Content, "\n\n")
+}
+
+// renderPlainTextNodes renders nodes as plain text
+func renderPlainTextNodes(nodes []*ADFNode, sep string) string {
+	var result []string
+
+	for _, node := range nodes {
+		text := renderPlainText(node)
+
+		// Skip empty blocks (e.g. rules) to avoid extra empty lines
+		if text == "" && strings.Contains(sep, "\n") {
+			continue
+		}
+
+		result = append(result, text)
+	}
+
+	return strings.Join(result, sep)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// renderMarkdown renders node as Markdown
+func renderMarkdown(n *ADFNode) string {
+	switch n.Type {
+	case ADF_PARAGRAPH:
+		return renderMarkdownInline(n.Content)
+	case ADF_HEADING:
+		return strings.Repeat("#", n.intAttr("level", 1)) + " " + renderMarkdownInline(n.Content)
+	case ADF_BULLET_LIST, ADF_ORDERED_LIST:
+		return renderMarkdownList(n)
+	case ADF_LIST_ITEM:
+		return renderMarkdownBlocks(n.Content, "\n")
+	case ADF_CODE_BLOCK:
+		return "```" + n.attr("language") + "\n" + renderPlainTextNodes(n.Content, "") + "\n```"
+	case ADF_BLOCKQUOTE, ADF_PANEL:
+		return prefixLines(renderMarkdownBlocks(n.Content, "\n\n"), "> ", "> ")
+	case ADF_RULE:
+		return "---"
+	case ADF_TABLE:
+		return renderMarkdownTable(n)
+	}
+
+	if n.isInline() {
+		return renderMarkdownInline([]*ADFNode{n})
+	}
+
+	return renderMarkdownBlocks(n.Content, "\n\n")
+}
+
+// renderMarkdownBlocks renders block nodes as Markdown
+func renderMarkdownBlocks(nodes []*ADFNode, sep string) string {
+	var result []string
+
+	for _, node := range nodes {
+		block := renderMarkdown(node)
+
+		if block != "" {
+			result = append(result, block)
+		}
+	}
+
+	return strings.Join(result, sep)
+}
+
+// renderMarkdownList renders list node as Markdown
+func renderMarkdownList(n *ADFNode) string {
+	var result []string
+
+	start := n.intAttr("order", 1)
+
+	for i, item := range n.Content {
+		marker := "- "
+
+		if n.Type == ADF_ORDERED_LIST {
+			marker = strconv.Itoa(start+i) + ". "
+		}
+
+		result = append(result, prefixLines(
+			renderMarkdown(item), marker, strings.Repeat(" ", len(marker)),
+		))
+	}
+
+	return strings.Join(result, "\n")
+}
+
+// renderMarkdownTable renders table node as Markdown
+func renderMarkdownTable(n *ADFNode) string {
+	var result []string
+
+	for i, row := range n.Content {
+		var cells []string
+
+		for _, cell := range row.Content {
+			text := renderMarkdownBlocks(cell.Content, " ")
+			cells = append(cells, strings.ReplaceAll(text, "|", `\|`))
+		}
+
+		result = append(result, "| "+strings.Join(cells, " | ")+" |")
+
+		if i == 0 {
+			result = append(result, "|"+strings.Repeat(" --- |", len(cells)))
+		}
+	}
+
+	return strings.Join(result, "\n")
+}
+
+// renderMarkdownInline renders inline nodes as Markdown
+func renderMarkdownInline(nodes []*ADFNode) string {
+	var buf strings.Builder
+
+	for _, node := range nodes {
+		switch node.Type {
+		case ADF_TEXT:
+			buf.WriteString(applyMarkdownMarks(node.Text, node.Marks))
+		case ADF_HARD_BREAK:
+			buf.WriteString("  \n")
+		case ADF_INLINE_CARD:
+			buf.WriteString("<" + node.attr("url") + ">")
+		case ADF_MEDIA:
+			if node.attr("url") != "" {
+				buf.WriteString("![" + node.attr("alt") + "](" + node.attr("url") + ")")
+			}
+		case ADF_MENTION, ADF_EMOJI, ADF_STATUS:
+			buf.WriteString(getADFInlineText(node))
+		default:
+			buf.WriteString(renderMarkdownInline(node.Content))
+		}
+	}
+
+	return buf.String()
+}
+
+// applyMarkdownMarks applies marks to text
+func applyMarkdownMarks(text string, marks []*ADFMark) string {
+	var link string
+
+	for _, mark := range getSortedMarks(marks) {
+		switch mark.Type {
+		case ADF_MARK_CODE:
+			text = "`" + text + "`"
+		case ADF_MARK_EM:
+			text = "*" + text + "*"
+		case ADF_MARK_STRONG:
+			text = "**" + text + "**"
+		case ADF_MARK_STRIKE:
+			text = "~~" + text + "~~"
+		case ADF_MARK_LINK:
+			link, _ = mark.Attrs["href"].(string)
+		}
+	}
+
+	if link != "" {
+		text = "[" + text + "](" + link + ")"
+	}
+
+	return text
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// renderHTML renders node as HTML
+func renderHTML(n *ADFNode) string {
+	switch n.Type {
+	case ADF_TEXT:
+		return applyHTMLMarks(html.EscapeString(n.Text), n.Marks)
+	case ADF_HARD_BREAK:
+		return "<br/>"
+	case ADF_INLINE_CARD:
+		link := html.EscapeString(n.attr("url"))
+
+		if !isSafeURL(n.attr("url")) {
+			return link
+		}
+
+		return `<a href="` + link + `">` + link + `</a>`
+	case ADF_MEDIA:
+		if n.attr("url") == "" {
+			return ""
+		}
+
+		if !isSafeURL(n.attr("url")) && n.attr("alt") != "" {
+			return html.EscapeString(n.attr("alt"))
+		} else if !isSafeURL(n.attr("url")) {
+			return html.EscapeString(n.attr("url"))
+		}
+
+		return `<img src="` + html.EscapeString(n.attr("url")) + `" alt="` + html.EscapeString(n.attr("alt")) + `"/>`
+	case ADF_MENTION, ADF_EMOJI, ADF_STATUS:
+		return html.EscapeString(getADFInlineText(n))
+	case ADF_RULE:
+		return "<hr/>"
+	case ADF_HEADING:
+		level := strconv.Itoa(min(max(n.intAttr("level", 1), 1), 6))
+		return "<h" + level + ">" + renderHTMLNodes(n.Content) + "</h" + level + ">"
+	case ADF_CODE_BLOCK:
+		class := ""
+
+		if n.attr("language") != "" {
+			class = ` class="language-` + html.EscapeString(n.attr("language")) + `"`
+		}
+
+		return "<pre><code" + class + ">" + html.EscapeString(renderPlainTextNodes(n.Content, "")) + "</code></pre>"
+	}
+
+	tag := getHTMLTag(n.Type)
+
+	if tag == "" {
+		return renderHTMLNodes(n.Content)
+	}
+
+	return "<" + tag + ">" + renderHTMLNodes(n.Content) + "</" + tag + ">"
+}
+
+// renderHTMLNodes renders nodes as HTML
+func renderHTMLNodes(nodes []*ADFNode) string {
+	var buf strings.Builder
+
+	for _, node := range nodes {
+		buf.WriteString(renderHTML(node))
+	}
+
+	return buf.String()
+}
+
+// applyHTMLMarks applies marks to text
+func applyHTMLMarks(text string, marks []*ADFMark) string {
+	var link string
+
+	for _, mark := range getSortedMarks(marks) {
+		switch mark.Type {
+		case ADF_MARK_CODE:
+			text = "<code>" + text + "</code>"
+		case ADF_MARK_EM:
+			text = "<em>" + text + "</em>"
+		case ADF_MARK_STRONG:
+			text = "<strong>" + text + "</strong>"
+		case ADF_MARK_STRIKE:
+			text = "<s>" + text + "</s>"
+		case ADF_MARK_UNDERLINE:
+			text = "<u>" + text + "</u>"
+		case ADF_MARK_LINK:
+			link, _ = mark.Attrs["href"].(string)
+		}
+	}
+
+	if link != "" && isSafeURL(link) {
+		text = `<a href="` + html.EscapeString(link) + `">` + text + "</a>"
+	}
+
+	return text
+}
+
+// isSafeURL returns true if URL can be used as link or image source in HTML
+// (only http, https, mailto and relative URLs are allowed)
+func isSafeURL(link string) bool {
+	if link == "" || link != strings.TrimSpace(link) {
+		return false
+	}
+
+	u, err := url.Parse(link)
+
+	if err != nil {
+		return false
+	}
+
+	switch u.Scheme {
+	case "", "http", "https", "mailto":
+		return true
+	}
+
+	return false
+}
+
+// getHTMLTag returns HTML tag for given block node type
+func getHTMLTag(nodeType string) string {
+	switch nodeType {
+	case ADF_PARAGRAPH:
+		return "p"
+	case ADF_BULLET_LIST:
+		return "ul"
+	case ADF_ORDERED_LIST:
+		return "ol"
+	case ADF_LIST_ITEM:
+		return "li"
+	case ADF_BLOCKQUOTE, ADF_PANEL:
+		return "blockquote"
+	case ADF_TABLE:
+		return "table"
+	case ADF_TABLE_ROW:
+		return "tr"
+	case ADF_TABLE_HEADER:
+		return "th"
+	case ADF_TABLE_CELL:
+		return "td"
+	}
+
+	return ""
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getADFInlineText returns text of mention, emoji or status node
+func getADFInlineText(n *ADFNode) string {
+	switch {
+	case n.attr("text") != "":
+		return n.attr("text")
+	case n.attr("shortName") != "":
+		return n.attr("shortName")
+	}
+
+	return ""
+}
+
+// getSortedMarks returns marks in order of application (code is always
+// the innermost mark)
+func getSortedMarks(marks []*ADFMark) []*ADFMark {
+	result := make([]*ADFMark, 0, len(marks))
+
+	for _, mark := range marks {
+		if mark.Type == ADF_MARK_CODE {
+			result = append([]*ADFMark{mark}, result...)
+		} else {
+			result = append(result, mark)
+		}
+	}
+
+	return result
+}
+
+// prefixLines adds prefix to the first line and indent to all other lines
+func prefixLines(text, prefix, indent string) string {
+	lines := strings.Split(text, "\n")
+
+	for i := range lines {
+		switch {
+		case i == 0:
+			lines[i] = prefix + lines[i]
+		case lines[i] != "":
+			lines[i] = indent + lines[i]
+		default:
+			lines[i] = strings.TrimRight(indent, " ")
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// unmarshalRichText decodes text field which can contain either string
+// (Server) or ADF document (Cloud)
+func unmarshalRichText(data []byte, text *string, doc **ADFNode) error {
+	data = []byte(strings.TrimSpace(string(data)))
+
+	if len(data) == 0 || data[0] != '{' {
+		return json.Unmarshal(data, text)
+	}
+
+	*doc = &ADFNode{}
+	err := json.Unmarshal(data, *doc)
+
+	if err != nil {
+		return err
+	}
+
+	*text = (*doc).PlainText()
+
+	return nil
+}
@@ -265,6 +265,8 @@ type IssueFields struct {
 	FixVersions                   []*Version         `json:"fixVersions"`
 	Issuelinks                    []*Link            `json:"issuelinks"`
 	Custom                        CustomFieldsStore  `json:"-"`
+	DescriptionADF                *ADFNode           `json:"-"` // Jira Cloud only
+	EnvironmentADF                *ADFNode           `json:"-"` // Jira Cloud only
 }
 
 // CustomFieldsStore is store for custom fields data
@@ -272,13 +274,15 @@ type CustomFieldsStore map[string]json.RawMessage
 
 // IssueType contains info about issue type
 type IssueType struct {
-	Statuses    []*Status `json:"statuses"`
-	ID          string    `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	IconURL     string    `json:"iconUrl"`
-	AvatarID    int       `json:"avatarId"`
-	IsSubTask   bool      `json:"subtask"`
+	Fields         map[string]*FieldMeta `json:"fields"`
+	Statuses       []*Status             `json:"statuses"`
+	ID             string                `json:"id"`
+	Name           string                `json:"name"`
+	Description    string                `json:"description"`
+	IconURL        string                `json:"iconUrl"`
+	AvatarID       int                   `json:"avatarId"`
+	HierarchyLevel int                   `json:"hierarchyLevel"` // Jira Cloud only
+	IsSubTask      bool                  `json:"subtask"`
 }
 
 // Priority contains priority info
@@ -371,12 +375,13 @@ type CommentCollection struct {
 
 // Comment contains info about comment
 type Comment struct {
-	ID           string `json:"id"`
-	Body         string `json:"body"`
-	Created      *Date  `json:"created"`
-	Updated      *Date  `json:"updated"`
-	Author       *User  `json:"author"`
-	UpdateAuthor *User  `json:"updateAuthor"`
+	ID           string   `json:"id"`
+	Body         string   `json:"body"`
+	Created      *Date    `json:"created"`
+	Updated      *Date    `json:"updated"`
+	Author       *User    `json:"author"`
+	UpdateAuthor *User    `json:"updateAuthor"`
+	BodyADF      *ADFNode `json:"-"` // Jira Cloud only
 }
 
 // FILTERS ////////////////////////////////////////////////////////////////////////// //
@@ -444,17 +449,20 @@ type RemoteLinkParams struct {
 
 // RemoteLink contains info about remote link
 type RemoteLink struct {
-	ID          int             `json:"id"`
-	GlobalID    string          `json:"globalId"`
-	Application *RemoteLinkApp  `json:"application"`
-	Info        *RemoteLinkInfo `json:"object"`
+	ID           int             `json:"id"`
+	GlobalID     string          `json:"globalId"`
+	Relationship string          `json:"relationship"`
+	Application  *RemoteLinkApp  `json:"application"`
+	Info         *RemoteLinkInfo `json:"object"`
 }
 
 // RemoteLinkInfo contains basic info about remote link
 type RemoteLinkInfo struct {
-	URL   string          `json:"url"`
-	Title string          `json:"title"`
-	Icon  *RemoteLinkIcon `json:"icon"`
+	URL     string            `json:"url"`
+	Title   string            `json:"title"`
+	Summary string            `json:"summary,omitempty"`
+	Icon    *RemoteLinkIcon   `json:"icon,omitempty"`
+	Status  *RemoteLinkStatus `json:"status,omitempty"`
 }
 
 // RemoteLinkApp contains info about link app
@@ -465,7 +473,15 @@ type RemoteLinkApp struct {
 
 // RemoteLinkIcon contains icon URL
 type RemoteLinkIcon struct {
-	URL string `json:"url16x16"`
+	URL   string `json:"url16x16"`
+	Title string `json:"title,omitempty"`
+	Link  string `json:"link,omitempty"`
+}
+
+// RemoteLinkStatus contains info about remote object status
+type RemoteLinkStatus struct {
+	Icon       *RemoteLinkIcon `json:"icon,omitempty"`
+	IsResolved bool            `json:"resolved"`
 }
 
 // SCREENS ////////////////////////////////////////////////////////////////////////// //
@@ -522,11 +538,15 @@ type Field struct {
 
 // FieldMeta contains field meta
 type FieldMeta struct {
+	Schema          *FieldSchema      `json:"schema"`
+	FieldID         string            `json:"fieldId"`
+	Key             string            `json:"key"`
 	Name            string            `json:"name"`
 	AutoCompleteURL string            `json:"autoCompleteUrl"`
 	Operations      []string          `json:"operations"`
 	AllowedValues   []*FieldMetaValue `json:"allowedValues"`
 	IsRequired      bool              `json:"required"`
+	HasDefaultValue bool              `json:"hasDefaultValue"`
 }
 
 // FieldSchema contains field schema
@@ -574,6 +594,32 @@ type CreateMetaParams struct {
 	ProjectKeys    []string `query:"projectKeys"`
 	IssueTypeIDs   []string `query:"issuetypeIds"`
 	IssueTypeNames []string `query:"issuetypeNames"`
+	Expand         []string `query:"expand"`
+}
+
+// CreateMetaPageParams is params for fetching paginated metadata for creating issues
+type CreateMetaPageParams struct {
+	StartAt    int `query:"startAt"`
+	MaxResults int `query:"maxResults"`
+}
+
+// CreateMetaIssueTypeCollection is collection of issue types available for
+// creating issues
+type CreateMetaIssueTypeCollection struct {
+	Data       []*IssueType `json:"values"`
+	StartAt    int          `json:"startAt"`
+	MaxResults int          `json:"maxResults"`
+	Total      int          `json:"total"`
+	IsLast     bool         `json:"isLast"`
+}
+
+// CreateMetaFieldCollection is collection of fields available for creating issues
+type CreateMetaFieldCollection struct {
+	Data       []*FieldMeta `json:"values"`
+	StartAt    int          `json:"startAt"`
+	MaxResults int          `json:"maxResults"`
+	Total      int          `json:"total"`
+	IsLast     bool         `json:"isLast"`
 }
 
 // Project contains info about project
@@ -607,17 +653,20 @@ type SearchParams struct {
 	Fields                 []string `query:"fields"`
 	Expand                 []string `query:"expand"`
 	JQL                    string   `query:"jql"`
-	StartAt                int      `query:"startAt"`
+	NextPageToken          string   `query:"nextPageToken"` // Jira Cloud only
+	StartAt                int      `query:"startAt"`       // Jira Server/Data Center only
 	MaxResults             int      `query:"maxResults"`
 	DisableQueryValidation bool     `query:"validateQuery,reverse"`
 }
 
 // SearchResults contains search result
 type SearchResults struct {
-	StartAt    int      `json:"startAt"`
-	MaxResults int      `json:"maxResults"`
-	Total      int      `json:"total"`
-	Issues     []*Issue `json:"issues"`
+	NextPageToken string   `json:"nextPageToken"` // Jira Cloud only
+	StartAt       int      `json:"startAt"`
+	MaxResults    int      `json:"maxResults"`
+	Total         int      `json:"total"`
+	Issues        []*Issue `json:"issues"`
+	IsLast        bool     `json:"isLast"` // Jira Cloud only
 }
 
 // PROPERTY ///////////////////////////////////////////////////////////////////////// //
@@ -686,14 +735,16 @@ type Transition struct {
 
 // UserParams is params for fetching user info
 type UserParams struct {
-	Username string   `query:"username"`
-	Key      string   `query:"key"`
-	Expand   []string `query:"expand"`
+	Username  string   `query:"username"`
+	Key       string   `query:"key"`
+	AccountID string   `query:"accountId"`
+	Expand    []string `query:"expand"`
 }
 
 // UserPermissionParams is permissions for fetching users by permissions
 type UserPermissionParams struct {
 	Username    string   `query:"username"`
+	AccountID   string   `query:"accountId"`
 	Permissions []string `query:"permissions"`
 	IssueKey    string   `query:"issueKey"`
 	ProjectKey  string   `query:"projectKey"`
@@ -704,6 +755,8 @@ type UserPermissionParams struct {
 // UserSearchParams is permissions for searching users
 type UserSearchParams struct {
 	Username        string `query:"username"`
+	Query           string `query:"query"`
+	AccountID       string `query:"accountId"`
 	StartAt         int    `query:"startAt"`
 	MaxResults      int    `query:"maxResults"`
 	IncludeInactive bool   `query:"includeInactive"`
@@ -724,6 +777,8 @@ type User struct {
 	AvatarURL   *AvatarURL  `json:"avatarUrls"`
 	Name        string      `json:"name"`
 	Key         string      `json:"key"`
+	AccountID   string      `json:"accountId"`
+	AccountType string      `json:"accountType"`
 	Email       string      `json:"emailAddress"`
 	DisplayName string      `json:"displayName"`
 	TimeZone    string      `json:"timeZone"`
@@ -794,6 +849,20 @@ type WatchersInfo struct {
 	IsWatching bool    `json:"isWatching"`
 }
 
+// SPRINTS ////////////////////////////////////////////////////////////////////////// //
+
+// Sprint contains info about sprint
+type Sprint struct {
+	StartDate     *Date  `json:"startDate"`
+	EndDate       *Date  `json:"endDate"`
+	CompleteDate  *Date  `json:"completeDate"`
+	Name          string `json:"name"`
+	State         string `json:"state"`
+	Goal          string `json:"goal"`
+	ID            int    `json:"id"`
+	OriginBoardID int    `json:"originBoardId"`
+}
+
 // WORK LOG ///////////////////////////////////////////////////////////////////////// //
 
 // WorklogCollection is worklog collection
@@ -806,15 +875,17 @@ type WorklogCollection struct {
 
 // Worklog is worklog record
 type Worklog struct {
-	ID               string `json:"id"`
-	Comment          string `json:"comment"`
-	TimeSpent        string `json:"timeSpent"`
-	Created          *Date  `json:"created"`
-	Updated          *Date  `json:"updated"`
-	Started          *Date  `json:"started"`
-	Author           *User  `json:"author"`
-	UpdateAuthor     *User  `json:"updateAuthor"`
-	TimeSpentSeconds int    `json:"timeSpentSeconds"`
+	ID               string   `json:"id"`
+	IssueID          string   `json:"issueId"`
+	Comment          string   `json:"comment"`
+	TimeSpent        string   `json:"timeSpent"`
+	Created          *Date    `json:"created"`
+	Updated          *Date    `json:"updated"`
+	Started          *Date    `json:"started"`
+	Author           *User    `json:"author"`
+	UpdateAuthor     *User    `json:"updateAuthor"`
+	CommentADF       *ADFNode `json:"-"` // Jira Cloud only
+	TimeSpentSeconds int      `json:"timeSpentSeconds"`
 }
 
 // PICKER /////////////////////////////////////////////////////////////////////////// //
@@ -916,6 +987,7 @@ type ServerInfo struct {
 	Version        string         `json:"version"`
 	SCMInfo        string         `json:"scmInfo"`
 	ServerTitle    string         `json:"serverTitle"`
+	DeploymentType string         `json:"deploymentType"`
 	VersionNumbers []int          `json:"versionNumbers"`
 	BuildNumber    int            `json:"buildNumber"`
 	HealthChecks   []*HealthCheck `json:"healthChecks"`
@@ -958,6 +1030,28 @@ type WorkflowScheme struct {
 	IsDraft           bool                  `json:"draft"`
 }
 
+// WEBHOOKS ///////////////////////////////////////////////////////////////////////// //
+
+// Webhook contains info about webhook
+type Webhook struct {
+	LastUpdated            *Timestamp      `json:"lastUpdated"`
+	Filters                *WebhookFilters `json:"filters"`
+	ID                     string          `json:"-"`
+	Name                   string          `json:"name"`
+	URL                    string          `json:"url"`
+	Self                   string          `json:"self"`
+	LastUpdatedUser        string          `json:"lastUpdatedUser"`
+	LastUpdatedDisplayName string          `json:"lastUpdatedDisplayName"`
+	Events                 []string        `json:"events"`
+	IsBodyExcluded         bool            `json:"excludeBody"`
+	IsEnabled              bool            `json:"enabled"`
+}
+
+// WebhookFilters contains webhook filters
+type WebhookFilters struct {
+	JQL string `json:"issue-related-events-section"`
+}
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // nullBytes is a byte slice with "null" word
@@ -971,6 +1065,11 @@ func (d *Date) UnmarshalJSON(b []byte) error {
 
 	if bytes.Contains(b, []byte("T")) {
 		d.Time, err = time.Parse("2006-01-02T15:04:05-0700", strings.Trim(string(b), "\""))
+
+		// Agile API and webhooks use RFC3339 dates with colon in zone offset
+		if err != nil {
+			d.Time, err = time.Parse(time.RFC3339, strings.Trim(string(b), "\""))
+		}
 	} else {
 		d.Time, err = time.Parse("2006-01-02", strings.Trim(string(b), "\""))
 	}
@@ -1001,26 +1100,106 @@ func (f *IssueFields) UnmarshalJSON(b []byte) error {
 	}
 
 	for key, chunk := range f.Custom {
-		if field, found := knownFields[key]; found {
-			err = json.Unmarshal(chunk, field.Addr().Interface())
-
-			if err != nil {
-				return err
-			}
+		field, found := knownFields[key]
 
+		switch {
+		case key == "description":
+			err = unmarshalRichText(chunk, &f.Description, &f.DescriptionADF)
+			delete(f.Custom, key)
+		case key == "environment":
+			err = unmarshalRichText(chunk, &f.Environment, &f.EnvironmentADF)
+			delete(f.Custom, key)
+		case found:
+			err = json.Unmarshal(chunk, field.Addr().Interface())
 			delete(f.Custom, key)
-		} else {
-			if !strings.HasPrefix(key, "customfield_") {
-				delete(f.Custom, key)
-			} else if bytes.Equal(chunk, nullBytes) {
-				delete(f.Custom, key)
-			}
+		case !strings.HasPrefix(key, "customfield_"):
+			delete(f.Custom, key)
+		case bytes.Equal(chunk, nullBytes):
+			delete(f.Custom, key)
+		}
+
+		if err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
+// UnmarshalJSON is a custom Comment unmarshaler
+func (c *Comment) UnmarshalJSON(b []byte) error {
+	type comment Comment
+
+	data := &struct {
+		*comment
+		Body json.RawMessage `json:"body"`
+	}{comment: (*comment)(c)}
+
+	err := json.Unmarshal(b, data)
+
+	if err != nil || data.Body == nil {
+		return err
+	}
+
+	return unmarshalRichText(data.Body, &c.Body, &c.BodyADF)
+}
+
+// UnmarshalJSON is a custom CreateMetaIssueTypeCollection unmarshaler
+func (c *CreateMetaIssueTypeCollection) UnmarshalJSON(b []byte) error {
+	type collection CreateMetaIssueTypeCollection
+
+	// Jira Cloud returns issue types in "issueTypes" field instead of "values"
+	data := &struct {
+		*collection
+		CloudData []*IssueType `json:"issueTypes"`
+	}{collection: (*collection)(c)}
+
+	err := json.Unmarshal(b, data)
+
+	if err == nil && c.Data == nil {
+		c.Data = data.CloudData
+	}
+
+	return err
+}
+
+// UnmarshalJSON is a custom CreateMetaFieldCollection unmarshaler
+func (c *CreateMetaFieldCollection) UnmarshalJSON(b []byte) error {
+	type collection CreateMetaFieldCollection
+
+	// Jira Cloud returns fields in "fields" field instead of "values"
+	data := &struct {
+		*collection
+		CloudData []*FieldMeta `json:"fields"`
+	}{collection: (*collection)(c)}
+
+	err := json.Unmarshal(b, data)
+
+	if err == nil && c.Data == nil {
+		c.Data = data.CloudData
+	}
+
+	return err
+}
+
+// UnmarshalJSON is a custom Worklog unmarshaler
+func (w *Worklog) UnmarshalJSON(b []byte) error {
+	type worklog Worklog
+
+	data := &struct {
+		*worklog
+		Comment json.RawMessage `json:"comment"`
+	}{worklog: (*worklog)(w)}
+
+	err := json.Unmarshal(b, data)
+
+	if err != nil || data.Comment == nil {
+		return err
+	}
+
+	return unmarshalRichText(data.Comment, &w.Comment, &w.CommentADF)
+}
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // Has returns true if custom field with given name exists in store
@@ -1094,6 +1273,11 @@ func (p CreateMetaParams) ToQuery() string {
 	return paramsToQuery(p)
 }
 
+// ToQuery converts params to URL query
+func (p CreateMetaPageParams) ToQuery() string {
+	return paramsToQuery(p)
+}
+
 // ToQuery converts params to URL query
 func (p PermissionsParams) ToQuery() string {
 	return paramsToQuery(p)
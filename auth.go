@@ -9,7 +9,15 @@ package jira
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/valyala/fasthttp"
 )
 
 // ////////////////////////////////////////////////////////////////////////////////// //
@@ -20,6 +28,36 @@ type Auth interface {
 	Encode() string
 }
 
+// AuthRefresher is interface for authorization methods with credentials which
+// can expire and must be refreshed
+type AuthRefresher interface {
+	Auth
+
+	// IsExpired returns true if credentials must be refreshed before request
+	IsExpired() bool
+
+	// Refresh refreshes credentials
+	Refresh(api *API) error
+}
+
+// AuthCookie is interface for authorization methods which use cookie instead
+// of Authorization header
+type AuthCookie interface {
+	Auth
+
+	// Cookie returns name and value of authorization cookie
+	Cookie() (string, string)
+}
+
+// AuthCloser is interface for authorization methods which must release
+// credentials on API close
+type AuthCloser interface {
+	Auth
+
+	// Close releases credentials
+	Close(api *API) error
+}
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // AuthBasic is struct with data for basic authorization
@@ -33,17 +71,119 @@ type AuthToken struct {
 	Token string
 }
 
+// AuthTokenStrict is struct with data for personal token authorization with
+// strict Jira Server/Data Center personal token format validation
+type AuthTokenStrict struct {
+	Token string
+}
+
+// AuthCloudToken is struct with data for Atlassian Cloud API token authorization
+type AuthCloudToken struct {
+	Email string
+	Token string
+}
+
+// AuthOAuth is struct with data for OAuth 2.0 (3LO) authorization. Tokens are
+// refreshed automatically on expiration or if Jira returns 401 status code.
+type AuthOAuth struct {
+	// OnTokenUpdate is called every time tokens are updated, so they can be
+	// saved to persistent storage
+	OnTokenUpdate func(token OAuthToken)
+
+	ClientID     string
+	ClientSecret string
+	RedirectURI  string
+	TokenURL     string // Token endpoint URL (Jira Data Center endpoint is used if empty)
+
+	// Token contains initial tokens. Use GetToken for reading actual tokens after
+	// passing auth to API.
+	Token OAuthToken
+
+	mu        sync.RWMutex
+	refreshMu sync.Mutex
+}
+
+// AuthSession is struct with data for session cookie authorization. Session is
+// created on the first request and recreated automatically if it has expired.
+type AuthSession struct {
+	User     string
+	Password string
+
+	cookieName  string
+	cookieValue string
+
+	mu      sync.RWMutex
+	loginMu sync.Mutex
+}
+
+// OAuthToken contains OAuth 2.0 access and refresh tokens
+type OAuthToken struct {
+	Expiry       time.Time `json:"expiry"`
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+}
+
+// TokenValidator is function for validating personal and API tokens
+type TokenValidator func(token string) error
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// OAuthTokenURLCloud is URL of Atlassian Cloud OAuth 2.0 token endpoint
+const OAuthTokenURLCloud = "https://auth.atlassian.com/oauth/token"
+
+// oauthExpiryDelta is time before token expiration when token considered expired
+const oauthExpiryDelta = 30 * time.Second
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 var (
-	ErrEmptyUser        = errors.New("User can't be empty")
-	ErrEmptyPassword    = errors.New("Password can't be empty")
-	ErrEmptyToken       = errors.New("Token can't be empty")
-	ErrTokenWrongLength = errors.New("Token length must be equal to 44")
+	ErrEmptyUser         = errors.New("User can't be empty")
+	ErrEmptyPassword     = errors.New("Password can't be empty")
+	ErrEmptyToken        = errors.New("Token can't be empty")
+	ErrTokenWrongLength  = errors.New("Token length must be equal to 44")
+	ErrTokenWrongSymbols = errors.New("Token contains whitespace or control symbols")
+	ErrEmptyEmail        = errors.New("Email can't be empty")
+	ErrEmptyClientID     = errors.New("Client ID can't be empty")
+	ErrEmptyRefreshToken = errors.New("Refresh token can't be empty")
+	ErrEmptyAuthCode     = errors.New("Authorization code can't be empty")
 )
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
+// ValidateTokenRelaxed checks that token is not empty and doesn't contain
+// whitespace or control symbols
+func ValidateTokenRelaxed(token string) error {
+	if token == "" {
+		return ErrEmptyToken
+	}
+
+	for _, r := range token {
+		if r <= ' ' || r == 0x7F {
+			return ErrTokenWrongSymbols
+		}
+	}
+
+	return nil
+}
+
+// ValidateTokenStrict checks that token is a valid Jira Server/Data Center
+// personal access token
+func ValidateTokenStrict(token string) error {
+	err := ValidateTokenRelaxed(token)
+
+	if err != nil {
+		return err
+	}
+
+	if len(token) != 44 {
+		return ErrTokenWrongLength
+	}
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
 // Validate validates authorization data
 func (a AuthBasic) Validate() error {
 	switch {
@@ -63,17 +203,396 @@ func (a AuthBasic) Encode() string {
 
 // Validate validates authorization data
 func (a AuthToken) Validate() error {
+	return ValidateTokenRelaxed(a.Token)
+}
+
+// ValidateWith validates authorization data using given token validator
+func (a AuthToken) ValidateWith(validator TokenValidator) error {
+	if validator == nil {
+		return ValidateTokenRelaxed(a.Token)
+	}
+
+	return validator(a.Token)
+}
+
+// Encode encodes data for authorization
+func (a AuthToken) Encode() string {
+	return "Bearer " + a.Token
+}
+
+// Validate validates authorization data
+func (a AuthTokenStrict) Validate() error {
+	return ValidateTokenStrict(a.Token)
+}
+
+// Encode encodes data for authorization
+func (a AuthTokenStrict) Encode() string {
+	return "Bearer " + a.Token
+}
+
+// Validate validates authorization data
+func (a AuthCloudToken) Validate() error {
+	if a.Email == "" {
+		return ErrEmptyEmail
+	}
+
+	return ValidateTokenRelaxed(a.Token)
+}
+
+// Encode encodes data for authorization
+func (a AuthCloudToken) Encode() string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(a.Email+":"+a.Token))
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Validate validates authorization data
+func (a *AuthOAuth) Validate() error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
 	switch {
-	case a.Token == "":
+	case a.ClientID == "":
+		return ErrEmptyClientID
+	case a.Token.AccessToken == "" && a.Token.RefreshToken == "":
 		return ErrEmptyToken
-	case len(a.Token) != 44:
-		return ErrTokenWrongLength
 	}
 
 	return nil
 }
 
 // Encode encodes data for authorization
-func (a AuthToken) Encode() string {
-	return "Bearer " + a.Token
+func (a *AuthOAuth) Encode() string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return "Bearer " + a.Token.AccessToken
+}
+
+// GetToken returns current tokens
+func (a *AuthOAuth) GetToken() OAuthToken {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return a.Token
+}
+
+// IsExpired returns true if access token is expired or will expire soon
+func (a *AuthOAuth) IsExpired() bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.Token.AccessToken == "" {
+		return true
+	}
+
+	return !a.Token.Expiry.IsZero() && time.Now().Add(oauthExpiryDelta).After(a.Token.Expiry)
+}
+
+// Refresh obtains new access token using refresh token. Concurrent calls are
+// serialized, and token is refreshed only once if it was updated while waiting.
+func (a *AuthOAuth) Refresh(api *API) error {
+	a.mu.RLock()
+	accessToken := a.Token.AccessToken
+	a.mu.RUnlock()
+
+	a.refreshMu.Lock()
+	defer a.refreshMu.Unlock()
+
+	a.mu.RLock()
+	refreshToken := a.Token.RefreshToken
+	isUpdated := a.Token.AccessToken != accessToken
+	a.mu.RUnlock()
+
+	if isUpdated && !a.IsExpired() {
+		return nil
+	}
+
+	if refreshToken == "" {
+		return ErrEmptyRefreshToken
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "refresh_token")
+	form.Set("refresh_token", refreshToken)
+
+	return a.requestToken(api.Client, a.getTokenURL(api.url), form)
+}
+
+// Exchange exchanges authorization code received on redirect URI to tokens
+func (a *AuthOAuth) Exchange(jiraURL, code string) error {
+	switch {
+	case a.ClientID == "":
+		return ErrEmptyClientID
+	case code == "":
+		return ErrEmptyAuthCode
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+
+	return a.requestToken(&fasthttp.Client{}, a.getTokenURL(jiraURL), form)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Validate validates authorization data
+func (a *AuthSession) Validate() error {
+	switch {
+	case a.User == "":
+		return ErrEmptyUser
+	case a.Password == "":
+		return ErrEmptyPassword
+	}
+
+	return nil
+}
+
+// Encode encodes data for authorization
+func (a *AuthSession) Encode() string {
+	return ""
+}
+
+// Cookie returns name and value of session cookie
+func (a *AuthSession) Cookie() (string, string) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return a.cookieName, a.cookieValue
+}
+
+// IsExpired returns true if session is not created yet
+func (a *AuthSession) IsExpired() bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return a.cookieValue == ""
+}
+
+// Refresh creates new session. Concurrent calls are serialized, and session is
+// created only once if it was updated while waiting.
+// https://docs.atlassian.com/software/jira/docs/api/REST/9.16.0/#auth/1/session-login
+func (a *AuthSession) Refresh(api *API) error {
+	_, cookieValue := a.Cookie()
+
+	a.loginMu.Lock()
+	defer a.loginMu.Unlock()
+
+	_, currentValue := a.Cookie()
+
+	if currentValue != "" && currentValue != cookieValue {
+		return nil
+	}
+
+	req := fasthttp.AcquireRequest()
+	resp := fasthttp.AcquireResponse()
+
+	defer fasthttp.ReleaseRequest(req)
+	defer fasthttp.ReleaseResponse(resp)
+
+	reqData, err := json.Marshal(map[string]string{
+		"username": a.User,
+		"password": a.Password,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	req.SetRequestURI(api.url + "/rest/auth/1/session")
+	req.Header.SetMethod("POST")
+	req.Header.SetContentType("application/json")
+	req.SetBody(reqData)
+
+	err = api.Client.Do(req, resp)
+
+	if err != nil {
+		return err
+	}
+
+	switch resp.StatusCode() {
+	case 200:
+		// ok
+	case 401:
+		return ErrNoAuth
+	case 403:
+		return ErrLoginDenied
+	default:
+		return makeUnknownError(resp.StatusCode())
+	}
+
+	result := &struct {
+		Session struct {
+			Name  string `json:"name"`
+			Value string `json:"value"`
+		} `json:"session"`
+	}{}
+
+	err = json.Unmarshal(resp.Body(), result)
+
+	if err != nil {
+		return fmt.Errorf("Can't decode session info: %w", err)
+	}
+
+	if result.Session.Value == "" {
+		return errors.New("Can't create session: response doesn't contain session info")
+	}
+
+	if result.Session.Name == "" {
+		result.Session.Name = "JSESSIONID"
+	}
+
+	a.mu.Lock()
+	a.cookieName, a.cookieValue = result.Session.Name, result.Session.Value
+	a.mu.Unlock()
+
+	return nil
+}
+
+// Close destroys current session
+// https://docs.atlassian.com/software/jira/docs/api/REST/9.16.0/#auth/1/session-logout
+func (a *AuthSession) Close(api *API) error {
+	name, value := a.Cookie()
+
+	if value == "" {
+		return nil
+	}
+
+	req := fasthttp.AcquireRequest()
+	resp := fasthttp.AcquireResponse()
+
+	defer fasthttp.ReleaseRequest(req)
+	defer fasthttp.ReleaseResponse(resp)
+
+	req.SetRequestURI(api.url + "/rest/auth/1/session")
+	req.Header.SetMethod("DELETE")
+	req.Header.SetCookie(name, value)
+
+	err := api.Client.Do(req, resp)
+
+	if err != nil {
+		return err
+	}
+
+	switch resp.StatusCode() {
+	case 200, 204:
+		// ok
+	case 401:
+		return nil
+	default:
+		return makeUnknownError(resp.StatusCode())
+	}
+
+	a.mu.Lock()
+	a.cookieName, a.cookieValue = "", ""
+	a.mu.Unlock()
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getAuthState returns current credentials used for requests authorization
+func getAuthState(auth Auth) string {
+	if cookieAuth, ok := auth.(AuthCookie); ok {
+		_, value := cookieAuth.Cookie()
+		return value
+	}
+
+	return auth.Encode()
+}
+
+// getTokenURL returns URL of token endpoint
+func (a *AuthOAuth) getTokenURL(jiraURL string) string {
+	if a.TokenURL != "" {
+		return a.TokenURL
+	}
+
+	return strings.TrimRight(jiraURL, "/") + "/rest/oauth2/latest/token"
+}
+
+// requestToken sends request to token endpoint and updates tokens
+func (a *AuthOAuth) requestToken(client *fasthttp.Client, tokenURL string, form url.Values) error {
+	req := fasthttp.AcquireRequest()
+	resp := fasthttp.AcquireResponse()
+
+	defer fasthttp.ReleaseRequest(req)
+	defer fasthttp.ReleaseResponse(resp)
+
+	form.Set("client_id", a.ClientID)
+
+	if a.ClientSecret != "" {
+		form.Set("client_secret", a.ClientSecret)
+	}
+
+	if a.RedirectURI != "" {
+		form.Set("redirect_uri", a.RedirectURI)
+	}
+
+	req.SetRequestURI(tokenURL)
+	req.Header.SetMethod("POST")
+	req.Header.SetContentType("application/x-www-form-urlencoded")
+	req.SetBodyString(form.Encode())
+
+	err := client.Do(req, resp)
+
+	if err != nil {
+		return err
+	}
+
+	result := &struct {
+		AccessToken      string `json:"access_token"`
+		RefreshToken     string `json:"refresh_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+		ExpiresIn        int    `json:"expires_in"`
+	}{}
+
+	err = json.Unmarshal(resp.Body(), result)
+
+	if resp.StatusCode() != 200 {
+		switch {
+		case err == nil && result.ErrorDescription != "":
+			return fmt.Errorf("Can't obtain OAuth token: %s", result.ErrorDescription)
+		case err == nil && result.Error != "":
+			return fmt.Errorf("Can't obtain OAuth token: %s", result.Error)
+		default:
+			return fmt.Errorf("Can't obtain OAuth token (status code %d)", resp.StatusCode())
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("Can't decode OAuth token: %w", err)
+	}
+
+	if result.AccessToken == "" {
+		return errors.New("Can't obtain OAuth token: response doesn't contain access token")
+	}
+
+	a.mu.Lock()
+
+	a.Token.AccessToken = result.AccessToken
+
+	// Refresh token rotation is optional, so we keep the previous token
+	// if server didn't send a new one
+	if result.RefreshToken != "" {
+		a.Token.RefreshToken = result.RefreshToken
+	}
+
+	if result.ExpiresIn > 0 {
+		a.Token.Expiry = time.Now().Add(time.Duration(result.ExpiresIn) * time.Second)
+	} else {
+		a.Token.Expiry = time.Time{}
+	}
+
+	token := a.Token
+
+	a.mu.Unlock()
+
+	if a.OnTokenUpdate != nil {
+		a.OnTokenUpdate(token)
+	}
+
+	return nil
 }
@@ -0,0 +1,184 @@
+package jira
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"strings"
+	"sync"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// bulkBatchSize is maximum number of keys in one search request
+const bulkBatchSize = 50
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// IssueResult contains result of fetching issue in bulk request
+type IssueResult struct {
+	Issue *Issue
+	Error error
+	Key   string
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// GetIssues fetches issues with given keys using at most concurrency parallel
+// requests. Issues are fetched in batches using search where possible, issues
+// which can't be found by search (or batches with invalid keys) are fetched one
+// by one. Results have the same order as keys.
+func (api *API) GetIssues(keys []string, params IssueParams, concurrency int) []*IssueResult {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var uniqKeys []string
+
+	issues := map[string]*IssueResult{}
+
+	for _, key := range keys {
+		if issues[key] == nil {
+			issues[key] = &IssueResult{Key: key}
+			uniqKeys = append(uniqKeys, key)
+		}
+	}
+
+	var batches [][]string
+
+	for i := 0; i < len(uniqKeys); i += bulkBatchSize {
+		batches = append(batches, uniqKeys[i:min(i+bulkBatchSize, len(uniqKeys))])
+	}
+
+	var mu sync.Mutex
+
+	runPool(len(batches), concurrency, func(index int) {
+		found, err := api.searchIssuesByKeys(batches[index], params)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case err == ErrInvalidInput:
+			// JQL query contains invalid or nonexistent keys, so issues
+			// from batch must be fetched one by one
+			return
+		case err != nil:
+			for _, key := range batches[index] {
+				issues[key].Error = err
+			}
+
+			return
+		}
+
+		for _, issue := range found {
+			if issues[issue.Key] != nil {
+				issues[issue.Key].Issue = issue
+			}
+		}
+	})
+
+	// Issues can be missing from search results if they were moved
+	// (and have new key) or batch contains invalid keys
+	var missing []*IssueResult
+
+	for _, key := range uniqKeys {
+		if issues[key].Issue == nil && issues[key].Error == nil {
+			missing = append(missing, issues[key])
+		}
+	}
+
+	runPool(len(missing), concurrency, func(index int) {
+		missing[index].Issue, missing[index].Error = api.GetIssue(missing[index].Key, params)
+	})
+
+	result := make([]*IssueResult, len(keys))
+
+	for i, key := range keys {
+		issue := *issues[key]
+		result[i] = &issue
+	}
+
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// searchIssuesByKeys searches issues with given keys
+func (api *API) searchIssuesByKeys(keys []string, params IssueParams) ([]*Issue, error) {
+	quotedKeys := make([]string, len(keys))
+
+	for i, key := range keys {
+		quotedKeys[i] = quoteJQL(key)
+	}
+
+	resp, statusCode, err := api.search(SearchParams{
+		JQL:                    "key in (" + strings.Join(quotedKeys, ",") + ")",
+		Fields:                 params.Fields,
+		Expand:                 params.Expand,
+		MaxResults:             len(keys),
+		DisableQueryValidation: !api.isCloud,
+	})
+
+	// Jira returns 400 with decoded error messages if any key in query
+	// doesn't exist, so status code must be checked before error
+	switch {
+	case statusCode == 400:
+		return nil, ErrInvalidInput
+	case err != nil:
+		return nil, err
+	}
+
+	switch statusCode {
+	case 200:
+		return resp.Issues, nil
+	case 401:
+		return nil, ErrNoAuth
+	default:
+		return nil, makeUnknownError(statusCode)
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// runPool runs function for each index using given number of workers
+func runPool(num, workers int, fn func(index int)) {
+	if num == 0 {
+		return
+	}
+
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+
+	for range min(num, workers) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for index := range jobs {
+				fn(index)
+			}
+		}()
+	}
+
+	for i := range num {
+		jobs <- i
+	}
+
+	close(jobs)
+	wg.Wait()
+}
+
+// quoteJQL quotes string for using in JQL query
+func quoteJQL(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+
+	return `"` + s + `"`
+}
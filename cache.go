@@ -0,0 +1,314 @@
+package jira
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Cacheable endpoints
+const (
+	CACHE_FIELDS       = "/rest/api/2/field"
+	CACHE_PRIORITIES   = "/rest/api/2/priority"
+	CACHE_STATUSES     = "/rest/api/2/status"
+	CACHE_ISSUE_TYPES  = "/rest/api/2/issuetype"
+	CACHE_RESOLUTIONS  = "/rest/api/2/resolution"
+	CACHE_PROJECTS     = "/rest/api/2/project"
+	CACHE_AUTOCOMPLETE = "/rest/api/2/jql/autocompletedata"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Cache is interface for responses cache backend
+type Cache interface {
+	// Get returns cached data
+	Get(key string) ([]byte, bool)
+
+	// Set saves data to cache
+	Set(key string, data []byte, ttl time.Duration)
+
+	// Delete removes data from cache
+	Delete(key string)
+}
+
+// CacheTTL contains TTL for cacheable endpoints (CACHE_*). Responses from
+// endpoints without TTL are not cached.
+type CacheTTL map[string]time.Duration
+
+// MemoryCache is simple in-memory cache
+type MemoryCache struct {
+	items     map[string]*memoryCacheItem
+	lastSweep time.Time
+	mu        sync.RWMutex
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// memoryCacheSweepInterval is interval between removing expired items from
+// in-memory cache
+const memoryCacheSweepInterval = time.Minute
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// memoryCacheItem contains cached data
+type memoryCacheItem struct {
+	expiry time.Time
+	data   []byte
+}
+
+// apiCache contains API cache configuration and state
+type apiCache struct {
+	backend Cache
+	ttl     CacheTTL
+	keys    map[string]map[string]bool // endpoint → cached keys
+	flight  *flightGroup
+
+	mu sync.Mutex
+}
+
+// flightGroup coalesces concurrent identical requests
+type flightGroup struct {
+	calls map[string]*flightCall
+	mu    sync.Mutex
+}
+
+// flightCall contains in-flight request result
+type flightCall struct {
+	data       []byte
+	err        error
+	statusCode int
+	wg         sync.WaitGroup
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// DefaultCacheTTL is default TTL for cacheable endpoints
+var DefaultCacheTTL = CacheTTL{
+	CACHE_FIELDS:       time.Hour,
+	CACHE_PRIORITIES:   time.Hour,
+	CACHE_STATUSES:     time.Hour,
+	CACHE_ISSUE_TYPES:  time.Hour,
+	CACHE_RESOLUTIONS:  time.Hour,
+	CACHE_PROJECTS:     15 * time.Minute,
+	CACHE_AUTOCOMPLETE: time.Hour,
+}
+
+// ErrNilCache is returned if cache backend is nil
+var ErrNilCache = errors.New("Cache can't be nil")
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewMemoryCache creates new in-memory cache
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{items: map[string]*memoryCacheItem{}}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// EnableCache enables caching of responses from slow-changing metadata endpoints
+// (CACHE_*). Concurrent identical requests to these endpoints are coalesced
+// into one request. Cache must be configured before API is used concurrently.
+func (api *API) EnableCache(cache Cache, ttl CacheTTL) error {
+	if cache == nil {
+		return ErrNilCache
+	}
+
+	if ttl == nil {
+		ttl = DefaultCacheTTL
+	}
+
+	api.cache = &apiCache{
+		backend: cache,
+		ttl:     ttl,
+		keys:    map[string]map[string]bool{},
+		flight:  &flightGroup{},
+	}
+
+	return nil
+}
+
+// DisableCache disables responses caching. Cache must not be disabled while
+// API is used concurrently.
+func (api *API) DisableCache() {
+	api.cache = nil
+}
+
+// InvalidateCache removes cached responses of given endpoints (CACHE_*). All
+// cached responses are removed if endpoints are not set.
+func (api *API) InvalidateCache(endpoints ...string) {
+	if api.cache == nil {
+		return
+	}
+
+	api.cache.invalidate(endpoints)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Get returns cached data
+func (c *MemoryCache) Get(key string) ([]byte, bool) {
+	c.mu.RLock()
+	item := c.items[key]
+	c.mu.RUnlock()
+
+	if item == nil || time.Now().After(item.expiry) {
+		return nil, false
+	}
+
+	return item.data, true
+}
+
+// Set saves data to cache
+func (c *MemoryCache) Set(key string, data []byte, ttl time.Duration) {
+	now := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.items == nil {
+		c.items = map[string]*memoryCacheItem{}
+	}
+
+	// Expired items are ignored on read, so we remove them periodically
+	// instead of checking all items on every write
+	if now.Sub(c.lastSweep) >= memoryCacheSweepInterval {
+		for k, item := range c.items {
+			if now.After(item.expiry) {
+				delete(c.items, k)
+			}
+		}
+
+		c.lastSweep = now
+	}
+
+	c.items[key] = &memoryCacheItem{expiry: now.Add(ttl), data: data}
+}
+
+// Delete removes data from cache
+func (c *MemoryCache) Delete(key string) {
+	c.mu.Lock()
+	delete(c.items, key)
+	c.mu.Unlock()
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// sendCachedRequest returns cached response or sends GET request and caches
+// its response
+func (api *API) sendCachedRequest(uri string, params Parameters, ttl time.Duration) (int, []byte, error) {
+	key := api.url + uri
+
+	if query := params.ToQuery(); query != "" {
+		key += "?" + query
+	}
+
+	data, ok := api.cache.backend.Get(key)
+
+	if ok {
+		return 200, data, nil
+	}
+
+	return api.cache.flight.do(key, func() (int, []byte, error) {
+		statusCode, data, err := api.sendRequest("GET", uri, params, nil)
+
+		if err == nil && statusCode == 200 {
+			api.cache.set(uri, key, data, ttl)
+		}
+
+		return statusCode, data, err
+	})
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getTTL returns TTL for given request or 0 if request is not cacheable
+func (c *apiCache) getTTL(method, uri string) time.Duration {
+	if c == nil || method != "GET" {
+		return 0
+	}
+
+	return c.ttl[uri]
+}
+
+// set saves data to cache backend
+func (c *apiCache) set(endpoint, key string, data []byte, ttl time.Duration) {
+	c.mu.Lock()
+
+	if c.keys[endpoint] == nil {
+		c.keys[endpoint] = map[string]bool{}
+	}
+
+	c.keys[endpoint][key] = true
+
+	c.mu.Unlock()
+
+	c.backend.Set(key, data, ttl)
+}
+
+// invalidate removes cached data for given endpoints
+func (c *apiCache) invalidate(endpoints []string) {
+	c.mu.Lock()
+
+	if len(endpoints) == 0 {
+		for endpoint := range c.keys {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+
+	var keys []string
+
+	for _, endpoint := range endpoints {
+		for key := range c.keys[endpoint] {
+			keys = append(keys, key)
+		}
+
+		delete(c.keys, endpoint)
+	}
+
+	c.mu.Unlock()
+
+	for _, key := range keys {
+		c.backend.Delete(key)
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// do executes given function once for all concurrent calls with the same key
+func (g *flightGroup) do(key string, fn func() (int, []byte, error)) (int, []byte, error) {
+	g.mu.Lock()
+
+	if g.calls == nil {
+		g.calls = map[string]*flightCall{}
+	}
+
+	if call, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		call.wg.Wait()
+		return call.statusCode, call.data, call.err
+	}
+
+	call := &flightCall{}
+	call.wg.Add(1)
+	g.calls[key] = call
+
+	g.mu.Unlock()
+
+	call.statusCode, call.data, call.err = fn()
+	call.wg.Done()
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+
+	return call.statusCode, call.data, call.err
+}
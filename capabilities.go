@@ -0,0 +1,228 @@
+package jira
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"sync"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Deployment types
+const (
+	DEPLOYMENT_SERVER      = "Server"
+	DEPLOYMENT_DATA_CENTER = "DataCenter"
+	DEPLOYMENT_CLOUD       = "Cloud"
+)
+
+// Capabilities
+const (
+	// CAP_LEGACY_CREATE_META is support of createmeta endpoint with all projects
+	// and issue types (removed in Jira 9.0)
+	CAP_LEGACY_CREATE_META = "legacy-create-meta"
+
+	// CAP_PAGINATED_CREATE_META is support of paginated createmeta endpoints
+	// (added in Jira 8.4)
+	CAP_PAGINATED_CREATE_META = "paginated-create-meta"
+)
+
+// capabilitiesRetryDelay is delay before the next detection attempt if
+// previous one failed
+const capabilitiesRetryDelay = time.Minute
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Capabilities contains info about Jira deployment and supported features
+type Capabilities struct {
+	Deployment   string
+	Version      string
+	MajorVersion int
+	MinorVersion int
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// apiCapabilities contains detected capabilities
+type apiCapabilities struct {
+	data    *Capabilities
+	err     error
+	errTime time.Time
+
+	mu       sync.RWMutex
+	detectMu sync.Mutex
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// capabilitiesMatrix contains checks for all known capabilities
+var capabilitiesMatrix = map[string]func(c *Capabilities) bool{
+	CAP_LEGACY_CREATE_META: func(c *Capabilities) bool {
+		return c.IsCloud() || !c.IsVersionAtLeast(9, 0)
+	},
+	CAP_PAGINATED_CREATE_META: func(c *Capabilities) bool {
+		return c.IsCloud() || c.IsVersionAtLeast(8, 4)
+	},
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Connect checks connection to Jira and detects deployment capabilities, so
+// methods can choose endpoints without additional requests
+func (api *API) Connect() error {
+	_, err := api.Capabilities()
+	return err
+}
+
+// Capabilities returns info about Jira deployment and supported features.
+// Capabilities are detected on connect (or the first call) and cached.
+func (api *API) Capabilities() (*Capabilities, error) {
+	if api.capabilities == nil {
+		return api.detectCapabilities()
+	}
+
+	caps, ok, err := api.capabilities.get()
+
+	if ok {
+		return caps, err
+	}
+
+	api.capabilities.detectMu.Lock()
+	defer api.capabilities.detectMu.Unlock()
+
+	caps, ok, err = api.capabilities.get()
+
+	if ok {
+		return caps, err
+	}
+
+	caps, err = api.detectCapabilities()
+	api.capabilities.set(caps, err)
+
+	return caps, err
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Supports returns true if deployment supports given capability (CAP_*)
+func (c *Capabilities) Supports(capability string) bool {
+	check := capabilitiesMatrix[capability]
+
+	if c == nil || check == nil {
+		return false
+	}
+
+	return check(c)
+}
+
+// IsCloud returns true if deployment is Jira Cloud
+func (c *Capabilities) IsCloud() bool {
+	return c != nil && c.Deployment == DEPLOYMENT_CLOUD
+}
+
+// IsVersionAtLeast returns true if Jira version is equal to or greater than
+// given version. Version is not checked for Jira Cloud.
+func (c *Capabilities) IsVersionAtLeast(major, minor int) bool {
+	switch {
+	case c == nil:
+		return false
+	case c.IsCloud(), c.MajorVersion > major:
+		return true
+	}
+
+	return c.MajorVersion == major && c.MinorVersion >= minor
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// detectCapabilities detects capabilities using info about server
+func (api *API) detectCapabilities() (*Capabilities, error) {
+	info, err := api.GetServerInfo(false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	caps := &Capabilities{
+		Deployment: info.DeploymentType,
+		Version:    info.Version,
+	}
+
+	if len(info.VersionNumbers) > 0 {
+		caps.MajorVersion = info.VersionNumbers[0]
+	}
+
+	if len(info.VersionNumbers) > 1 {
+		caps.MinorVersion = info.VersionNumbers[1]
+	}
+
+	switch {
+	case api.isCloud, caps.Deployment == DEPLOYMENT_CLOUD:
+		caps.Deployment = DEPLOYMENT_CLOUD
+	case caps.MajorVersion >= 10, api.isClustered():
+		// Jira 10+ is available only as Data Center
+		caps.Deployment = DEPLOYMENT_DATA_CENTER
+	default:
+		caps.Deployment = DEPLOYMENT_SERVER
+	}
+
+	return caps, nil
+}
+
+// isClustered returns true if Jira works in cluster mode (Data Center only)
+// https://docs.atlassian.com/software/jira/docs/api/REST/9.16.0/#api/2/cluster-getAllNodes
+func (api *API) isClustered() bool {
+	result := []map[string]any{}
+	statusCode, err := api.doRequest(
+		"GET", "/rest/api/2/cluster/nodes",
+		EmptyParameters{}, &result, nil, false,
+	)
+
+	return err == nil && statusCode == 200 && len(result) != 0
+}
+
+// supports returns true if deployment supports given capability
+func (api *API) supports(capability string) (bool, error) {
+	caps, err := api.Capabilities()
+
+	if err != nil {
+		return false, err
+	}
+
+	return caps.Supports(capability), nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// get returns cached capabilities or detection error. Second value is false
+// if capabilities must be detected.
+func (c *apiCapabilities) get() (*Capabilities, bool, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	switch {
+	case c.data != nil:
+		return c.data, true, nil
+	case c.err != nil && time.Since(c.errTime) < capabilitiesRetryDelay:
+		return nil, true, c.err
+	}
+
+	return nil, false, nil
+}
+
+// set caches detected capabilities or detection error
+func (c *apiCapabilities) set(caps *Capabilities, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.data, c.err = caps, err
+
+	if err != nil {
+		c.errTime = time.Now()
+	}
+}
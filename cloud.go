@@ -0,0 +1,89 @@
+package jira
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"net/url"
+	"strings"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// cloudUnsupportedEndpoints is list of endpoints removed from Jira Cloud
+// (method → endpoint templates)
+var cloudUnsupportedEndpoints = map[string][]string{
+	"GET": {
+		"/rest/api/2/group",
+		"/rest/api/2/user/avatars",
+		"/rest/api/2/workflow",
+	},
+}
+
+// cloudRemovedParams is list of query parameters removed from Jira Cloud by
+// GDPR changes (users can be identified only by account ID)
+// https://developer.atlassian.com/cloud/jira/platform/deprecation-notice-user-privacy-api-migration-guide/
+var cloudRemovedParams = []string{"username", "userKey"}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// isCloudSupported returns true if endpoint with given params is available
+// in Jira Cloud
+func isCloudSupported(method, uri string, params Parameters) bool {
+	endpoint := getEndpointTemplate(uri)
+
+	for _, unsupported := range cloudUnsupportedEndpoints[method] {
+		if endpoint == unsupported {
+			return false
+		}
+	}
+
+	query, _ := url.ParseQuery(params.ToQuery())
+
+	if strings.Contains(uri, "?") {
+		uriQuery, _ := url.ParseQuery(uri[strings.Index(uri, "?")+1:])
+
+		for name, values := range uriQuery {
+			query[name] = append(query[name], values...)
+		}
+	}
+
+	for _, param := range cloudRemovedParams {
+		if query.Get(param) != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// getRequestURI returns URI of endpoint for current deployment type
+func (api *API) getRequestURI(uri string) string {
+	if api.isCloud && strings.HasPrefix(uri, "/rest/api/2/") {
+		return "/rest/api/3/" + uri[12:]
+	}
+
+	return uri
+}
+
+// getUserParamName returns name of query parameter used for user identification
+func (api *API) getUserParamName() string {
+	if api.isCloud {
+		return "accountId"
+	}
+
+	return "username"
+}
+
+// getUserID returns user identifier used by current deployment type
+func (api *API) getUserID(user *User) string {
+	if api.isCloud {
+		return user.AccountID
+	}
+
+	return user.Name
+}
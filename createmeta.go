@@ -0,0 +1,133 @@
+package jira
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"slices"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getPaginatedCreateMeta fetches metadata for creating issues using paginated
+// endpoints and returns it in the same format as legacy createmeta endpoint
+func (api *API) getPaginatedCreateMeta(params CreateMetaParams) ([]*Project, error) {
+	var err error
+	var projects []*Project
+
+	if len(params.ProjectIDs) == 0 && len(params.ProjectKeys) == 0 {
+		projects, err = api.GetProjects(ExpandParameters{})
+
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		for _, projectIDOrKey := range append(slices.Clone(params.ProjectIDs), params.ProjectKeys...) {
+			project, err := api.GetProject(projectIDOrKey, ExpandParameters{})
+
+			if err != nil {
+				return nil, err
+			}
+
+			projects = append(projects, project)
+		}
+	}
+
+	var result []*Project
+
+	withFields := slices.Contains(params.Expand, "projects.issuetypes.fields")
+
+	for _, project := range projects {
+		project.IssueTypes, err = api.getCreateMetaIssueTypes(project.ID, params, withFields)
+
+		// Legacy endpoint doesn't return projects where user can't create issues
+		switch {
+		case err == ErrNoPerms, err == ErrNoContent:
+			continue
+		case err != nil:
+			return nil, err
+		case len(project.IssueTypes) == 0:
+			continue
+		}
+
+		result = append(result, project)
+	}
+
+	return result, nil
+}
+
+// getCreateMetaIssueTypes fetches all issue types for creating issues in project
+func (api *API) getCreateMetaIssueTypes(projectID string, params CreateMetaParams, withFields bool) ([]*IssueType, error) {
+	var result []*IssueType
+
+	pageParams := CreateMetaPageParams{}
+
+	for {
+		issueTypes, err := api.GetCreateMetaIssueTypes(projectID, pageParams)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, issueType := range issueTypes.Data {
+			if !isCreateMetaIssueTypeMatch(issueType, params) {
+				continue
+			}
+
+			if withFields {
+				issueType.Fields, err = api.getCreateMetaFields(projectID, issueType.ID)
+
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			result = append(result, issueType)
+		}
+
+		pageParams.StartAt += len(issueTypes.Data)
+
+		if issueTypes.IsLast || len(issueTypes.Data) == 0 || pageParams.StartAt >= issueTypes.Total {
+			return result, nil
+		}
+	}
+}
+
+// getCreateMetaFields fetches all fields for creating issues with given type
+func (api *API) getCreateMetaFields(projectID, issueTypeID string) (map[string]*FieldMeta, error) {
+	result := map[string]*FieldMeta{}
+	pageParams := CreateMetaPageParams{}
+
+	for {
+		fields, err := api.GetCreateMetaFields(projectID, issueTypeID, pageParams)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, field := range fields.Data {
+			result[field.FieldID] = field
+		}
+
+		pageParams.StartAt += len(fields.Data)
+
+		if fields.IsLast || len(fields.Data) == 0 || pageParams.StartAt >= fields.Total {
+			return result, nil
+		}
+	}
+}
+
+// isCreateMetaIssueTypeMatch returns true if issue type matches filters from
+// params
+func isCreateMetaIssueTypeMatch(issueType *IssueType, params CreateMetaParams) bool {
+	if len(params.IssueTypeIDs) == 0 && len(params.IssueTypeNames) == 0 {
+		return true
+	}
+
+	return slices.Contains(params.IssueTypeIDs, issueType.ID) ||
+		slices.Contains(params.IssueTypeNames, issueType.Name)
+}
@@ -0,0 +1,340 @@
+package jira
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Environment variables used by EnvProvider
+const (
+	ENV_URL      = "JIRA_URL"
+	ENV_USER     = "JIRA_USER"
+	ENV_PASSWORD = "JIRA_PASSWORD"
+	ENV_TOKEN    = "JIRA_TOKEN"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Credentials contains Jira URL and auth data
+type Credentials struct {
+	Auth Auth
+	URL  string
+}
+
+// CredentialsProvider is interface for credentials providers
+type CredentialsProvider interface {
+	// Credentials returns credentials or ErrNoCredentials if provider
+	// has no credentials
+	Credentials() (*Credentials, error)
+}
+
+// EnvProvider loads credentials from environment variables (JIRA_URL, JIRA_USER,
+// JIRA_PASSWORD and JIRA_TOKEN)
+type EnvProvider struct{}
+
+// NetrcProvider loads credentials from netrc file entry matching Jira host
+type NetrcProvider struct {
+	URL  string // Jira URL (JIRA_URL environment variable is used if empty)
+	File string // Path to netrc file (~/.netrc is used if empty)
+}
+
+// FileProvider loads token from file. File must not be accessible by group
+// and others.
+type FileProvider struct {
+	URL  string // Jira URL (JIRA_URL environment variable is used if empty)
+	User string // User email for Atlassian Cloud API token
+	File string // Path to file with token
+}
+
+// ChainProvider tries providers in order and returns credentials from the first
+// provider which has them
+type ChainProvider []CredentialsProvider
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Credentials providers errors
+var (
+	ErrNoCredentials  = errors.New("Credentials not found")
+	ErrInsecureFile   = errors.New("File with credentials is accessible by group or others")
+	ErrEmptyTokenFile = errors.New("Path to token file can't be empty")
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewAPIFromProvider creates new API struct using credentials from given provider
+func NewAPIFromProvider(provider CredentialsProvider) (*API, error) {
+	creds, err := provider.Credentials()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewAPI(creds.URL, creds.Auth)
+}
+
+// DefaultProvider returns chain of providers which loads credentials from
+// environment variables and ~/.netrc
+func DefaultProvider() ChainProvider {
+	return ChainProvider{EnvProvider{}, NetrcProvider{}}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Credentials returns credentials from environment variables
+func (p EnvProvider) Credentials() (*Credentials, error) {
+	jiraURL := os.Getenv(ENV_URL)
+	user := os.Getenv(ENV_USER)
+	password := os.Getenv(ENV_PASSWORD)
+	token := os.Getenv(ENV_TOKEN)
+
+	if jiraURL == "" || (password == "" && token == "") {
+		return nil, ErrNoCredentials
+	}
+
+	var auth Auth
+
+	switch {
+	case token != "":
+		auth = makeTokenAuth(user, token)
+	default:
+		auth = AuthBasic{User: user, Password: password}
+	}
+
+	return &Credentials{URL: jiraURL, Auth: auth}, validateCredentials(auth)
+}
+
+// Credentials returns credentials from netrc file
+func (p NetrcProvider) Credentials() (*Credentials, error) {
+	jiraURL := getCredentialsURL(p.URL)
+
+	if jiraURL == "" {
+		return nil, ErrNoCredentials
+	}
+
+	u, err := url.Parse(jiraURL)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't parse Jira URL: %w", err)
+	}
+
+	file := p.File
+
+	if file == "" {
+		file, err = getNetrcPath()
+
+		if err != nil {
+			return nil, ErrNoCredentials
+		}
+	}
+
+	// Like curl and git, we don't require strict permissions for netrc file
+	data, err := os.ReadFile(file)
+
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNoCredentials
+		}
+
+		return nil, err
+	}
+
+	login, password, ok := findNetrcEntry(string(data), u.Host, u.Hostname())
+
+	if !ok || password == "" {
+		return nil, ErrNoCredentials
+	}
+
+	var auth Auth
+
+	if login == "" || strings.Contains(login, "@") {
+		auth = makeTokenAuth(login, password)
+	} else {
+		auth = AuthBasic{User: login, Password: password}
+	}
+
+	return &Credentials{URL: jiraURL, Auth: auth}, validateCredentials(auth)
+}
+
+// Credentials returns credentials from token file
+func (p FileProvider) Credentials() (*Credentials, error) {
+	jiraURL := getCredentialsURL(p.URL)
+
+	switch {
+	case jiraURL == "":
+		return nil, ErrNoCredentials
+	case p.File == "":
+		return nil, ErrEmptyTokenFile
+	}
+
+	data, err := readCredentialsFile(p.File)
+
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNoCredentials
+		}
+
+		return nil, err
+	}
+
+	auth := makeTokenAuth(p.User, strings.TrimSpace(string(data)))
+
+	return &Credentials{URL: jiraURL, Auth: auth}, validateCredentials(auth)
+}
+
+// Credentials returns credentials from the first provider which has them
+func (p ChainProvider) Credentials() (*Credentials, error) {
+	for _, provider := range p {
+		creds, err := provider.Credentials()
+
+		switch {
+		case err == nil:
+			return creds, nil
+		case !errors.Is(err, ErrNoCredentials):
+			return nil, err
+		}
+	}
+
+	return nil, ErrNoCredentials
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// makeTokenAuth creates token auth method based on user name
+func makeTokenAuth(user, token string) Auth {
+	if strings.Contains(user, "@") {
+		return AuthCloudToken{Email: user, Token: token}
+	}
+
+	return AuthToken{Token: token}
+}
+
+// validateCredentials validates auth data from provider
+func validateCredentials(auth Auth) error {
+	err := auth.Validate()
+
+	if err != nil {
+		return fmt.Errorf("Invalid credentials: %w", err)
+	}
+
+	return nil
+}
+
+// getCredentialsURL returns given URL or URL from environment
+func getCredentialsURL(jiraURL string) string {
+	if jiraURL != "" {
+		return jiraURL
+	}
+
+	return os.Getenv(ENV_URL)
+}
+
+// getNetrcPath returns path to netrc file
+func getNetrcPath() (string, error) {
+	if os.Getenv("NETRC") != "" {
+		return os.Getenv("NETRC"), nil
+	}
+
+	homeDir, err := os.UserHomeDir()
+
+	if err != nil {
+		return "", err
+	}
+
+	if runtime.GOOS == "windows" {
+		return filepath.Join(homeDir, "_netrc"), nil
+	}
+
+	return filepath.Join(homeDir, ".netrc"), nil
+}
+
+// readCredentialsFile checks file permissions and reads its data
+func readCredentialsFile(file string) ([]byte, error) {
+	info, err := os.Stat(file)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0077 != 0 {
+		return nil, fmt.Errorf("%w (%s)", ErrInsecureFile, file)
+	}
+
+	return os.ReadFile(file)
+}
+
+// findNetrcEntry finds login and password for given host in netrc data
+func findNetrcEntry(data, host, hostname string) (string, string, bool) {
+	var login, password, defLogin, defPassword string
+	var inMachine, inDefault, found, hasDefault bool
+
+	tokens := strings.Fields(data)
+
+	for i := 0; i < len(tokens); i++ {
+		switch tokens[i] {
+		case "machine":
+			if found {
+				return login, password, true
+			}
+
+			if i+1 < len(tokens) {
+				i++
+				inMachine = tokens[i] == host || tokens[i] == hostname
+				inDefault, found = false, inMachine
+			}
+
+		case "default":
+			if found {
+				return login, password, true
+			}
+
+			inMachine, inDefault, hasDefault = false, true, true
+
+		case "login", "password", "account":
+			if i+1 >= len(tokens) {
+				break
+			}
+
+			i++
+
+			switch {
+			case inMachine && tokens[i-1] == "login":
+				login = tokens[i]
+			case inMachine && tokens[i-1] == "password":
+				password = tokens[i]
+			case inDefault && tokens[i-1] == "login":
+				defLogin = tokens[i]
+			case inDefault && tokens[i-1] == "password":
+				defPassword = tokens[i]
+			}
+
+		case "macdef":
+			// Macro definition ends with empty line, but since we work with
+			// tokens we just skip everything until the next entry
+			inMachine, inDefault = false, false
+
+			for i+1 < len(tokens) && tokens[i+1] != "machine" && tokens[i+1] != "default" {
+				i++
+			}
+		}
+	}
+
+	if found {
+		return login, password, true
+	}
+
+	return defLogin, defPassword, hasDefault
+}
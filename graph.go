@@ -0,0 +1,623 @@
+package jira
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Graph edge types (in addition to link type names)
+const (
+	GRAPH_EDGE_PARENT = "parent" // Parent → sub-task (or child issue in Jira Cloud)
+	GRAPH_EDGE_EPIC   = "epic"   // Epic → issue in epic
+)
+
+// GRAPH_BLOCKS is name of default link type used for graph analysis
+const GRAPH_BLOCKS = "Blocks"
+
+// GRAPH_EPIC_ISSUE_TYPE is default name of epic issue type in Jira Server/Data Center
+const GRAPH_EPIC_ISSUE_TYPE = "Epic"
+
+// DEFAULT_GRAPH_DEPTH is default graph crawling depth
+const DEFAULT_GRAPH_DEPTH = 3
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// GraphParams contains issue graph crawling params
+type GraphParams struct {
+	// LinkTypes is list of relations to follow: link type names (e.g. "Blocks"),
+	// inward/outward descriptions (e.g. "is blocked by"), GRAPH_EDGE_PARENT and
+	// GRAPH_EDGE_EPIC. All relations are followed if not set.
+	LinkTypes []string
+
+	// EpicLinkField is ID of "Epic Link" custom field (Jira Server/Data Center only)
+	EpicLinkField string
+
+	// EpicIssueType is name of epic issue type (useful for localized instances).
+	// If not set, issue types with hierarchy level 1 are treated as epics in
+	// Jira Cloud and issue type GRAPH_EPIC_ISSUE_TYPE in Jira Server/Data Center.
+	EpicIssueType string
+
+	// Depth is maximum crawling depth (DEFAULT_GRAPH_DEPTH is used if not set)
+	Depth int
+
+	// Concurrency is maximum number of parallel requests
+	Concurrency int
+}
+
+// IssueGraph is graph of issues relations
+type IssueGraph struct {
+	// Nodes contains issues (key → issue)
+	Nodes map[string]*Issue
+
+	// Edges contains relations between issues
+	Edges []*GraphEdge
+
+	// Roots contains keys of root issues
+	Roots []string
+}
+
+// GraphEdge is relation between issues. For links, From is the issue on the
+// outward side of relation (e.g. From blocks To).
+type GraphEdge struct {
+	From  string
+	To    string
+	Type  string
+	Label string
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// graphFields is list of fields required for building graph
+var graphFields = []string{
+	"summary", "status", "issuetype", "priority", "issuelinks",
+	"subtasks", "parent", "timeestimate",
+}
+
+// ErrGraphCycle is returned if graph contains cycle
+var ErrGraphCycle = errors.New("Graph contains cycle")
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// BuildIssueGraph crawls issues relations starting from root issues
+func (api *API) BuildIssueGraph(roots []string, params GraphParams) (*IssueGraph, error) {
+	if params.Depth <= 0 {
+		params.Depth = DEFAULT_GRAPH_DEPTH
+	}
+
+	if params.Concurrency <= 0 {
+		params.Concurrency = 4
+	}
+
+	fields := slices.Clone(graphFields)
+
+	if params.EpicLinkField != "" {
+		fields = append(fields, params.EpicLinkField)
+	}
+
+	graph := &IssueGraph{Nodes: map[string]*Issue{}, Roots: roots}
+	edges := map[GraphEdge]bool{}
+	visited := map[string]bool{}
+	level := slices.Clone(roots)
+
+	for depth := 0; len(level) != 0; depth++ {
+		var next []string
+
+		for _, result := range api.GetIssues(level, IssueParams{Fields: fields}, params.Concurrency) {
+			visited[result.Key] = true
+
+			if result.Error != nil {
+				if slices.Contains(roots, result.Key) {
+					return nil, fmt.Errorf("Can't fetch issue %s: %w", result.Key, result.Error)
+				}
+
+				continue
+			}
+
+			graph.Nodes[result.Key] = result.Issue
+
+			if depth >= params.Depth {
+				continue
+			}
+
+			related, err := api.getRelatedIssues(result.Issue, params)
+
+			if err != nil {
+				return nil, err
+			}
+
+			for _, rel := range related {
+				if !edges[*rel.edge] {
+					edges[*rel.edge] = true
+					graph.Edges = append(graph.Edges, rel.edge)
+				}
+
+				if graph.Nodes[rel.issue.Key] == nil {
+					graph.Nodes[rel.issue.Key] = rel.issue
+				}
+
+				if !visited[rel.issue.Key] && !slices.Contains(next, rel.issue.Key) {
+					next = append(next, rel.issue.Key)
+				}
+			}
+		}
+
+		level = next
+	}
+
+	return graph, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// FindCycles returns all cycles in relations with given link types ("Blocks"
+// is used if not set)
+func (g *IssueGraph) FindCycles(linkTypes ...string) [][]string {
+	adj := g.getAdjacency(linkTypes)
+	keys := g.getSortedKeys()
+
+	var result [][]string
+	var stack []string
+
+	index := 0
+	indexes := map[string]int{}
+	lowLinks := map[string]int{}
+	onStack := map[string]bool{}
+
+	// Tarjan's strongly connected components algorithm
+	var connect func(key string)
+
+	connect = func(key string) {
+		indexes[key], lowLinks[key] = index, index
+		index++
+		stack = append(stack, key)
+		onStack[key] = true
+
+		for _, to := range adj[key] {
+			if _, ok := indexes[to]; !ok {
+				connect(to)
+				lowLinks[key] = min(lowLinks[key], lowLinks[to])
+			} else if onStack[to] {
+				lowLinks[key] = min(lowLinks[key], indexes[to])
+			}
+		}
+
+		if lowLinks[key] != indexes[key] {
+			return
+		}
+
+		var component []string
+
+		for {
+			last := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[last] = false
+			component = append(component, last)
+
+			if last == key {
+				break
+			}
+		}
+
+		if len(component) > 1 || slices.Contains(adj[key], key) {
+			slices.Sort(component)
+			result = append(result, component)
+		}
+	}
+
+	for _, key := range keys {
+		if _, ok := indexes[key]; !ok {
+			connect(key)
+		}
+	}
+
+	slices.SortFunc(result, func(a, b []string) int {
+		return strings.Compare(a[0], b[0])
+	})
+
+	return result
+}
+
+// TopologicalOrder returns issues keys in order of relations with given link
+// types ("Blocks" is used if not set), so blocking issues go before blocked ones
+func (g *IssueGraph) TopologicalOrder(linkTypes ...string) ([]string, error) {
+	adj := g.getAdjacency(linkTypes)
+	inDegree := map[string]int{}
+
+	for _, targets := range adj {
+		for _, to := range targets {
+			inDegree[to]++
+		}
+	}
+
+	var queue, result []string
+
+	for _, key := range g.getSortedKeys() {
+		if inDegree[key] == 0 {
+			queue = append(queue, key)
+		}
+	}
+
+	// Kahn's algorithm
+	for len(queue) != 0 {
+		key := queue[0]
+		queue = queue[1:]
+		result = append(result, key)
+
+		for _, to := range adj[key] {
+			inDegree[to]--
+
+			if inDegree[to] == 0 {
+				queue = append(queue, to)
+			}
+		}
+	}
+
+	if len(result) != len(g.Nodes) {
+		return nil, ErrGraphCycle
+	}
+
+	return result, nil
+}
+
+// CriticalPath returns the longest chain of relations with given link types
+// ("Blocks" is used if not set). Weight function returns weight of issue (e.g.
+// remaining estimate), all issues have weight 1 if function is nil.
+func (g *IssueGraph) CriticalPath(weight func(issue *Issue) int, linkTypes ...string) ([]string, error) {
+	order, err := g.TopologicalOrder(linkTypes...)
+
+	if err != nil || len(order) == 0 {
+		return nil, err
+	}
+
+	if weight == nil {
+		weight = func(issue *Issue) int { return 1 }
+	}
+
+	adj := g.getAdjacency(linkTypes)
+	dist := map[string]int{}
+	prev := map[string]string{}
+
+	for _, key := range order {
+		dist[key] += weight(g.Nodes[key])
+	}
+
+	for _, key := range order {
+		for _, to := range adj[key] {
+			if dist[key]+weight(g.Nodes[to]) > dist[to] {
+				dist[to] = dist[key] + weight(g.Nodes[to])
+				prev[to] = key
+			}
+		}
+	}
+
+	last := order[0]
+
+	for _, key := range order {
+		if dist[key] > dist[last] {
+			last = key
+		}
+	}
+
+	path := []string{last}
+
+	for prev[last] != "" {
+		last = prev[last]
+		path = append(path, last)
+	}
+
+	slices.Reverse(path)
+
+	return path, nil
+}
+
+// DOT exports graph in Graphviz DOT format
+func (g *IssueGraph) DOT() string {
+	var buf strings.Builder
+
+	buf.WriteString("digraph issues {\n  rankdir=LR;\n")
+
+	for _, key := range g.getSortedKeys() {
+		label := key
+
+		if summary := getGraphIssueSummary(g.Nodes[key]); summary != "" {
+			label += "\n" + summary
+		}
+
+		fmt.Fprintf(&buf, "  %s [label=%s];\n", quoteDOT(key), quoteDOT(label))
+	}
+
+	for _, edge := range g.Edges {
+		fmt.Fprintf(&buf, "  %s -> %s [label=%s];\n", quoteDOT(edge.From), quoteDOT(edge.To), quoteDOT(edge.Label))
+	}
+
+	buf.WriteString("}\n")
+
+	return buf.String()
+}
+
+// Mermaid exports graph in Mermaid flowchart format
+func (g *IssueGraph) Mermaid() string {
+	var buf strings.Builder
+
+	buf.WriteString("graph LR\n")
+
+	for _, key := range g.getSortedKeys() {
+		label := key
+
+		if summary := getGraphIssueSummary(g.Nodes[key]); summary != "" {
+			label += ": " + summary
+		}
+
+		fmt.Fprintf(&buf, "  %s[\"%s\"]\n", getMermaidID(key), escapeMermaid(label))
+	}
+
+	for _, edge := range g.Edges {
+		fmt.Fprintf(
+			&buf, "  %s -->|\"%s\"| %s\n",
+			getMermaidID(edge.From), escapeMermaid(edge.Label), getMermaidID(edge.To),
+		)
+	}
+
+	return buf.String()
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// graphRelation is relation found while crawling
+type graphRelation struct {
+	edge  *GraphEdge
+	issue *Issue
+}
+
+// getRelatedIssues returns all issues related to given issue
+func (api *API) getRelatedIssues(issue *Issue, params GraphParams) ([]*graphRelation, error) {
+	var result []*graphRelation
+
+	fields := issue.Fields
+
+	if fields == nil {
+		return nil, nil
+	}
+
+	for _, link := range fields.Issuelinks {
+		if link.Type == nil || !isGraphLinkAllowed(params.LinkTypes, link.Type) {
+			continue
+		}
+
+		switch {
+		case link.OutwardIssue != nil:
+			result = append(result, &graphRelation{
+				&GraphEdge{issue.Key, link.OutwardIssue.Key, link.Type.Name, link.Type.Outward},
+				link.OutwardIssue,
+			})
+		case link.InwardIssue != nil:
+			result = append(result, &graphRelation{
+				&GraphEdge{link.InwardIssue.Key, issue.Key, link.Type.Name, link.Type.Outward},
+				link.InwardIssue,
+			})
+		}
+	}
+
+	if fields.Parent != nil {
+		edgeType := GRAPH_EDGE_PARENT
+
+		// In Jira Cloud epic is parent of issues, so such relation must be the
+		// same as relation found by searching epic issues
+		if api.isCloud && fields.Parent.Fields != nil &&
+			api.isEpicIssueType(fields.Parent.Fields.IssueType, params) {
+			edgeType = GRAPH_EDGE_EPIC
+		}
+
+		if isGraphEdgeAllowed(params.LinkTypes, edgeType) {
+			result = append(result, &graphRelation{
+				&GraphEdge{fields.Parent.Key, issue.Key, edgeType, edgeType},
+				fields.Parent,
+			})
+		}
+	}
+
+	if isGraphEdgeAllowed(params.LinkTypes, GRAPH_EDGE_PARENT) {
+		for _, subTask := range fields.SubTasks {
+			result = append(result, &graphRelation{
+				&GraphEdge{issue.Key, subTask.Key, GRAPH_EDGE_PARENT, GRAPH_EDGE_PARENT},
+				subTask,
+			})
+		}
+	}
+
+	if !isGraphEdgeAllowed(params.LinkTypes, GRAPH_EDGE_EPIC) {
+		return result, nil
+	}
+
+	var epicKey string
+
+	if params.EpicLinkField != "" &&
+		fields.Custom.Unmarshal(params.EpicLinkField, &epicKey) == nil && epicKey != "" {
+		result = append(result, &graphRelation{
+			&GraphEdge{epicKey, issue.Key, GRAPH_EDGE_EPIC, GRAPH_EDGE_EPIC},
+			&Issue{Key: epicKey},
+		})
+	}
+
+	if !api.isEpicIssueType(fields.IssueType, params) {
+		return result, nil
+	}
+
+	children, err := api.getEpicIssues(issue.Key, params)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, child := range children {
+		result = append(result, &graphRelation{
+			&GraphEdge{issue.Key, child.Key, GRAPH_EDGE_EPIC, GRAPH_EDGE_EPIC},
+			child,
+		})
+	}
+
+	return result, nil
+}
+
+// isEpicIssueType returns true if issue type is epic
+func (api *API) isEpicIssueType(issueType *IssueType, params GraphParams) bool {
+	switch {
+	case issueType == nil:
+		return false
+	case params.EpicIssueType != "":
+		return issueType.Name == params.EpicIssueType
+	case api.isCloud:
+		return issueType.HierarchyLevel == 1
+	}
+
+	return issueType.Name == GRAPH_EPIC_ISSUE_TYPE
+}
+
+// getEpicIssues returns all issues in epic
+func (api *API) getEpicIssues(epicKey string, params GraphParams) ([]*Issue, error) {
+	var jql string
+
+	switch {
+	case api.isCloud:
+		jql = "parent = " + quoteJQL(epicKey)
+	case params.EpicLinkField != "":
+		jql = getJQLFieldName(params.EpicLinkField) + " = " + quoteJQL(epicKey)
+	default:
+		return nil, nil
+	}
+
+	var result []*Issue
+
+	it := api.SearchIter(SearchParams{JQL: jql, Fields: []string{"summary", "status", "issuetype"}})
+
+	for it.Next() {
+		result = append(result, it.Issue())
+	}
+
+	return result, it.Err()
+}
+
+// getAdjacency returns adjacency list for edges with given types
+func (g *IssueGraph) getAdjacency(linkTypes []string) map[string][]string {
+	if len(linkTypes) == 0 {
+		linkTypes = []string{GRAPH_BLOCKS}
+	}
+
+	adj := map[string][]string{}
+
+	for _, edge := range g.Edges {
+		if !isGraphEdgeAllowed(linkTypes, edge.Type) || g.Nodes[edge.From] == nil || g.Nodes[edge.To] == nil {
+			continue
+		}
+
+		if !slices.Contains(adj[edge.From], edge.To) {
+			adj[edge.From] = append(adj[edge.From], edge.To)
+		}
+	}
+
+	for key := range adj {
+		slices.Sort(adj[key])
+	}
+
+	return adj
+}
+
+// getSortedKeys returns sorted keys of all nodes
+func (g *IssueGraph) getSortedKeys() []string {
+	keys := make([]string, 0, len(g.Nodes))
+
+	for key := range g.Nodes {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// isGraphLinkAllowed returns true if link type is in list of allowed types
+func isGraphLinkAllowed(allowed []string, linkType *LinkType) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+
+	for _, name := range allowed {
+		if strings.EqualFold(name, linkType.Name) ||
+			strings.EqualFold(name, linkType.Inward) ||
+			strings.EqualFold(name, linkType.Outward) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isGraphEdgeAllowed returns true if edge type is in list of allowed types
+func isGraphEdgeAllowed(allowed []string, edgeType string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+
+	return slices.ContainsFunc(allowed, func(name string) bool {
+		return strings.EqualFold(name, edgeType)
+	})
+}
+
+// getJQLFieldName returns name of field for using in JQL query (custom fields
+// are referenced by ID, e.g. customfield_10014 → cf[10014])
+func getJQLFieldName(fieldID string) string {
+	id, isCustom := strings.CutPrefix(fieldID, "customfield_")
+
+	if isCustom {
+		return "cf[" + id + "]"
+	}
+
+	return quoteJQL(fieldID)
+}
+
+// getGraphIssueSummary returns issue summary
+func getGraphIssueSummary(issue *Issue) string {
+	if issue == nil || issue.Fields == nil {
+		return ""
+	}
+
+	return issue.Fields.Summary
+}
+
+// quoteDOT quotes string for using in DOT
+func quoteDOT(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+
+	return `"` + s + `"`
+}
+
+// getMermaidID returns node ID for Mermaid
+func getMermaidID(key string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+
+		return '_'
+	}, key)
+}
+
+// escapeMermaid escapes label for using in Mermaid
+func escapeMermaid(s string) string {
+	return strings.ReplaceAll(s, `"`, "#quot;")
+}
@@ -0,0 +1,200 @@
+package jira
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"strings"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Instrumentation is interface for collecting metrics and traces of API calls
+type Instrumentation interface {
+	// StartSpan is called before API call. Returned span (if not nil) is finished
+	// after the call.
+	StartSpan(method, endpoint string) Span
+
+	// IncRequests is called after every API call and can be used for counters
+	IncRequests(info *CallInfo)
+
+	// ObserveDuration is called after every API call and can be used for
+	// histograms
+	ObserveDuration(info *CallInfo)
+}
+
+// Span is API call trace span
+type Span interface {
+	// Finish is called after API call
+	Finish(info *CallInfo)
+}
+
+// CallInfo contains info about API call
+type CallInfo struct {
+	// Error is call error
+	Error error
+
+	// Method is HTTP method
+	Method string
+
+	// Endpoint is endpoint template (e.g. /rest/api/2/issue/{key})
+	Endpoint string
+
+	// Duration is call duration
+	Duration time.Duration
+
+	// StatusCode is HTTP status code (-1 if request wasn't completed)
+	StatusCode int
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// endpointRoutes is list of known endpoint templates
+var endpointRoutes = [][]string{
+	splitPath("/rest/api/2/dashboard/{id}"),
+	splitPath("/rest/api/2/filter/defaultShareScope"),
+	splitPath("/rest/api/2/filter/favourite"),
+	splitPath("/rest/api/2/filter/{id}"),
+	splitPath("/rest/api/2/issue/createmeta"),
+	splitPath("/rest/api/2/issue/createmeta/{key}/issuetypes"),
+	splitPath("/rest/api/2/issue/createmeta/{key}/issuetypes/{id}"),
+	splitPath("/rest/api/2/issue/picker"),
+	splitPath("/rest/api/2/issue/{key}"),
+	splitPath("/rest/api/2/issue/{key}/comment"),
+	splitPath("/rest/api/2/issue/{key}/comment/{id}"),
+	splitPath("/rest/api/2/issue/{key}/editmeta"),
+	splitPath("/rest/api/2/issue/{key}/properties"),
+	splitPath("/rest/api/2/issue/{key}/properties/{property}"),
+	splitPath("/rest/api/2/issue/{key}/remotelink"),
+	splitPath("/rest/api/2/issue/{key}/remotelink/{id}"),
+	splitPath("/rest/api/2/issue/{key}/transitions"),
+	splitPath("/rest/api/2/issue/{key}/votes"),
+	splitPath("/rest/api/2/issue/{key}/watchers"),
+	splitPath("/rest/api/2/issue/{key}/worklog"),
+	splitPath("/rest/api/2/issue/{key}/worklog/{id}"),
+	splitPath("/rest/api/2/issueLink/{id}"),
+	splitPath("/rest/api/2/issueLinkType/{id}"),
+	splitPath("/rest/api/2/issuetype/{id}"),
+	splitPath("/rest/api/2/issuetype/{id}/alternatives"),
+	splitPath("/rest/api/2/priority/{id}"),
+	splitPath("/rest/api/2/project/{key}"),
+	splitPath("/rest/api/2/project/{key}/avatars"),
+	splitPath("/rest/api/2/project/{key}/components"),
+	splitPath("/rest/api/2/project/{key}/properties"),
+	splitPath("/rest/api/2/project/{key}/properties/{property}"),
+	splitPath("/rest/api/2/project/{key}/role"),
+	splitPath("/rest/api/2/project/{key}/role/{id}"),
+	splitPath("/rest/api/2/project/{key}/statuses"),
+	splitPath("/rest/api/2/project/{key}/version"),
+	splitPath("/rest/api/2/project/{key}/versions"),
+	splitPath("/rest/api/2/projectCategory/{id}"),
+	splitPath("/rest/api/2/resolution/{id}"),
+	splitPath("/rest/api/2/role/{id}"),
+	splitPath("/rest/api/2/screens/{id}/availableFields"),
+	splitPath("/rest/api/2/screens/{id}/tabs"),
+	splitPath("/rest/api/2/screens/{id}/tabs/{tab}/fields"),
+	splitPath("/rest/api/2/securitylevel/{id}"),
+	splitPath("/rest/api/2/status/{id}"),
+	splitPath("/rest/api/2/statuscategory/{id}"),
+	splitPath("/rest/api/2/version/{id}"),
+	splitPath("/rest/api/2/version/{id}/relatedIssueCounts"),
+	splitPath("/rest/api/2/version/{id}/unresolvedIssueCount"),
+	splitPath("/rest/api/2/workflowscheme/{id}"),
+	splitPath("/rest/api/2/workflowscheme/{id}/default"),
+	splitPath("/rest/api/2/workflowscheme/{id}/workflow"),
+	splitPath("/rest/webhooks/1.0/webhook/{id}"),
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// SetInstrumentation sets instrumentation for API calls. Instrumentation is
+// disabled if nil is passed.
+func (api *API) SetInstrumentation(instrumentation Instrumentation) {
+	api.instrumentation = instrumentation
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// doInstrumentedRequest calls request function and reports call info to
+// instrumentation
+func (api *API) doInstrumentedRequest(method, uri string, fn func() (int, error)) (int, error) {
+	info := &CallInfo{Method: method, Endpoint: getEndpointTemplate(uri)}
+	span := api.instrumentation.StartSpan(info.Method, info.Endpoint)
+
+	start := time.Now()
+	statusCode, err := fn()
+
+	info.Duration = time.Since(start)
+	info.StatusCode = statusCode
+	info.Error = err
+
+	api.instrumentation.IncRequests(info)
+	api.instrumentation.ObserveDuration(info)
+
+	if span != nil {
+		span.Finish(info)
+	}
+
+	return statusCode, err
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getEndpointTemplate returns endpoint template for given URI
+func getEndpointTemplate(uri string) string {
+	uri, _, _ = strings.Cut(uri, "?")
+	path := splitPath(uri)
+
+	var bestRoute []string
+	var bestScore int
+
+	// Route with most matched static segments wins, so /issue/createmeta
+	// has priority over /issue/{key}
+ROUTES:
+	for _, route := range endpointRoutes {
+		if len(route) != len(path) {
+			continue
+		}
+
+		score := 0
+
+		for i, segment := range route {
+			switch {
+			case strings.HasPrefix(segment, "{"):
+				continue
+			case segment != path[i]:
+				continue ROUTES
+			}
+
+			score++
+		}
+
+		if bestRoute == nil || score > bestScore {
+			bestRoute, bestScore = route, score
+		}
+	}
+
+	if bestRoute != nil {
+		return "/" + strings.Join(bestRoute, "/")
+	}
+
+	// Endpoints without parameters are used as is, and unknown endpoints
+	// have all segments with digits replaced by placeholder
+	for i, segment := range path {
+		if i > 2 && strings.ContainsAny(segment, "0123456789") {
+			path[i] = "{id}"
+		}
+	}
+
+	return "/" + strings.Join(path, "/")
+}
+
+// splitPath splits URI path to segments
+func splitPath(path string) []string {
+	return strings.Split(strings.Trim(path, "/"), "/")
+}
@@ -12,6 +12,8 @@ import (
 	"errors"
 	"fmt"
 	"runtime"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/valyala/fasthttp"
@@ -23,29 +25,46 @@ import (
 type API struct {
 	Client *fasthttp.Client // Client is client for http requests
 
-	url  string // Jira URL
-	auth string // Auth data
-}
+	url     string         // Jira URL
+	auth    Auth           // Auth data
+	cache   *apiCache      // Responses cache
+	limiter *rateLimiter   // Rate limiter
+	logger  *requestLogger // Requests logger
 
-// ////////////////////////////////////////////////////////////////////////////////// //
+	instrumentation Instrumentation  // Calls instrumentation
+	capabilities    *apiCapabilities // Detected capabilities
+
+	middlewares []Middleware // Requests middlewares
+
+	isChild bool // API created by WithAuth
+	isCloud bool // API works with Jira Cloud
+}
 
 // API errors
 var (
 	ErrEmptyURL     = errors.New("URL can't be empty")
+	ErrNilAuth      = errors.New("Auth can't be nil")
 	ErrNoPerms      = errors.New("User does not have permission to use Jira")
 	ErrInvalidInput = errors.New("Input is invalid")
 	ErrWrongLinkID  = errors.New("LinkId is not a valid number, or the remote issue link with the given id does not belong to the given issue")
 	ErrNoAuth       = errors.New("Calling user is not authenticated")
 	ErrNoContent    = errors.New("There is no content with the given ID, or the calling user does not have permission to view the content")
 	ErrGenResponse  = errors.New("Error occurs while generating the response")
+	ErrLoginDenied  = errors.New("Login is denied due to a CAPTCHA requirement, throttling, or any other reason")
+	ErrUnsupported  = errors.New("Operation is not supported by this Jira deployment")
+
+	ErrEmptyAccountID = errors.New("Account ID can't be empty")
 )
 
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // NewAPI create new API struct
 func NewAPI(url string, auth Auth) (*API, error) {
-	if url == "" {
+	switch {
+	case url == "":
 		return nil, ErrEmptyURL
+	case auth == nil:
+		return nil, ErrNilAuth
 	}
 
 	err := auth.Validate()
@@ -63,16 +82,82 @@ func NewAPI(url string, auth Auth) (*API, error) {
 			MaxConnsPerHost:     150,
 		},
 
-		url:  url,
-		auth: auth.Encode(),
+		url:          url,
+		auth:         auth,
+		capabilities: &apiCapabilities{},
 	}, nil
 }
 
+// NewCloudAPI creates new API struct for working with Jira Cloud. All requests
+// are sent to REST API v3, and users are identified by account ID.
+func NewCloudAPI(url string, auth Auth) (*API, error) {
+	// Cookie-based auth is not available in Jira Cloud
+	if _, ok := auth.(*AuthSession); ok {
+		return nil, ErrUnsupported
+	}
+
+	api, err := NewAPI(url, auth)
+
+	if err != nil {
+		return nil, err
+	}
+
+	api.isCloud = true
+
+	return api, nil
+}
+
+// IsCloud returns true if API works with Jira Cloud
+func (api *API) IsCloud() bool {
+	return api.isCloud
+}
+
 // SetUserAgent set user-agent string based on app name and version
 func (api *API) SetUserAgent(app, version string) {
 	api.Client.Name = getUserAgent(app, version)
 }
 
+// WithAuth creates child API which shares HTTP client (and connections pool)
+// with parent API but uses different auth data
+func (api *API) WithAuth(auth Auth) (*API, error) {
+	if auth == nil {
+		return nil, ErrNilAuth
+	}
+
+	err := auth.Validate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	child := *api
+
+	child.auth = auth
+	child.isChild = true
+
+	// Cached data depends on user permissions, so child can't use parent cache
+	child.cache = nil
+
+	return &child, nil
+}
+
+// Close releases auth credentials (e.g. destroys session) and closes idle
+// connections. Closing child API created by WithAuth doesn't affect connections
+// of parent API.
+func (api *API) Close() error {
+	var err error
+
+	if closer, ok := api.auth.(AuthCloser); ok {
+		err = closer.Close(api)
+	}
+
+	if !api.isChild {
+		api.Client.CloseIdleConnections()
+	}
+
+	return err
+}
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // GetConfiguration returns the information if the optional features in JIRA are
@@ -461,6 +546,130 @@ func (api *API) GetIssueRemoteLink(issueIDOrKey, linkID string) (*RemoteLink, er
 	}
 }
 
+// CreateIssueRemoteLink creates remote issue link. If link with the same GlobalID
+// already exists, it will be updated.
+// https://docs.atlassian.com/software/jira/docs/api/REST/6.4.13/#d2e4434
+func (api *API) CreateIssueRemoteLink(issueIDOrKey string, link *RemoteLink) (*RemoteLink, error) {
+	result := &RemoteLink{}
+	statusCode, err := api.doRequest(
+		"POST", "/rest/api/2/issue/"+issueIDOrKey+"/remotelink",
+		EmptyParameters{}, result, makeRemoteLinkRequest(link), true,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch statusCode {
+	case 200, 201:
+		created := *link
+		created.ID = result.ID
+		return &created, nil
+	case 400:
+		return nil, ErrInvalidInput
+	case 401:
+		return nil, ErrNoAuth
+	case 403:
+		return nil, ErrNoPerms
+	case 404:
+		return nil, ErrNoContent
+	default:
+		return nil, makeUnknownError(statusCode)
+	}
+}
+
+// UpdateIssueRemoteLink updates remote issue link with ID from given struct
+// https://docs.atlassian.com/software/jira/docs/api/REST/6.4.13/#d2e4497
+func (api *API) UpdateIssueRemoteLink(issueIDOrKey string, link *RemoteLink) error {
+	if link.ID == 0 {
+		return ErrWrongLinkID
+	}
+
+	statusCode, err := api.doRequest(
+		"PUT", "/rest/api/2/issue/"+issueIDOrKey+"/remotelink/"+strconv.Itoa(link.ID),
+		EmptyParameters{}, nil, makeRemoteLinkRequest(link), true,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	switch statusCode {
+	case 200, 204:
+		return nil
+	case 400:
+		return ErrInvalidInput
+	case 401:
+		return ErrNoAuth
+	case 403:
+		return ErrNoPerms
+	case 404:
+		return ErrNoContent
+	default:
+		return makeUnknownError(statusCode)
+	}
+}
+
+// DeleteIssueRemoteLink removes remote issue link with the given id
+// https://docs.atlassian.com/software/jira/docs/api/REST/6.4.13/#d2e4522
+func (api *API) DeleteIssueRemoteLink(issueIDOrKey, linkID string) error {
+	statusCode, err := api.doRequest(
+		"DELETE", "/rest/api/2/issue/"+issueIDOrKey+"/remotelink/"+linkID,
+		EmptyParameters{}, nil, nil, false,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	switch statusCode {
+	case 200, 204:
+		return nil
+	case 400:
+		return ErrWrongLinkID
+	case 401:
+		return ErrNoAuth
+	case 403:
+		return ErrNoPerms
+	case 404:
+		return ErrNoContent
+	default:
+		return makeUnknownError(statusCode)
+	}
+}
+
+// DeleteIssueRemoteLinkByGlobalID removes remote issue link with the given global id
+// https://docs.atlassian.com/software/jira/docs/api/REST/6.4.13/#d2e4461
+func (api *API) DeleteIssueRemoteLinkByGlobalID(issueIDOrKey, globalID string) error {
+	if globalID == "" {
+		return ErrInvalidInput
+	}
+
+	statusCode, err := api.doRequest(
+		"DELETE", "/rest/api/2/issue/"+issueIDOrKey+"/remotelink",
+		RemoteLinkParams{GlobalID: globalID}, nil, nil, false,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	switch statusCode {
+	case 200, 204:
+		return nil
+	case 400:
+		return ErrInvalidInput
+	case 401:
+		return ErrNoAuth
+	case 403:
+		return ErrNoPerms
+	case 404:
+		return ErrNoContent
+	default:
+		return makeUnknownError(statusCode)
+	}
+}
+
 // GetIssueTransitions returns a list of the transitions possible for this issue by the current user,
 // along with fields that are required and their types
 // https://docs.atlassian.com/software/jira/docs/api/REST/6.4.13/#d2e4051
@@ -491,7 +700,7 @@ func (api *API) GetIssueTransitions(issueIDOrKey string, params TransitionsParam
 }
 
 // GetIssueVotes returns sub-resource representing the voters on the issue
-// https://docs.atlassian.com/software/jira/docs/api/REST/6.4.13/#d2e4143
+// https://docs.atlassian.com/software/jira/docs/api/REST/9.16.0/#api/2/issue-getVotes
 func (api *API) GetIssueVotes(issueIDOrKey string) (*VotesInfo, error) {
 	result := &VotesInfo{}
 	statusCode, err := api.doRequest(
@@ -515,8 +724,56 @@ func (api *API) GetIssueVotes(issueIDOrKey string) (*VotesInfo, error) {
 	}
 }
 
+// AddVote casts a vote in favour of an issue by the current user
+// https://docs.atlassian.com/software/jira/docs/api/REST/9.16.0/#api/2/issue-addVote
+func (api *API) AddVote(issueIDOrKey string) error {
+	statusCode, err := api.doRequest(
+		"POST", "/rest/api/2/issue/"+issueIDOrKey+"/votes",
+		EmptyParameters{}, nil, nil, false,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	switch statusCode {
+	case 200, 204:
+		return nil
+	case 401:
+		return ErrNoAuth
+	case 404:
+		return ErrNoContent
+	default:
+		return makeUnknownError(statusCode)
+	}
+}
+
+// RemoveVote removes current user's vote from an issue
+// https://docs.atlassian.com/software/jira/docs/api/REST/9.16.0/#api/2/issue-removeVote
+func (api *API) RemoveVote(issueIDOrKey string) error {
+	statusCode, err := api.doRequest(
+		"DELETE", "/rest/api/2/issue/"+issueIDOrKey+"/votes",
+		EmptyParameters{}, nil, nil, false,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	switch statusCode {
+	case 200, 204:
+		return nil
+	case 401:
+		return ErrNoAuth
+	case 404:
+		return ErrNoContent
+	default:
+		return makeUnknownError(statusCode)
+	}
+}
+
 // GetIssueWatchers returns the list of watchers for the issue with the given key
-// https://docs.atlassian.com/software/jira/docs/api/REST/6.4.13/#d2e4232
+// https://docs.atlassian.com/software/jira/docs/api/REST/9.16.0/#api/2/issue-getIssueWatchers
 func (api *API) GetIssueWatchers(issueIDOrKey string) (*WatchersInfo, error) {
 	result := &WatchersInfo{}
 	statusCode, err := api.doRequest(
@@ -540,8 +797,132 @@ func (api *API) GetIssueWatchers(issueIDOrKey string) (*WatchersInfo, error) {
 	}
 }
 
+// AddWatcher adds a user to an issue's watcher list. User is username for
+// Jira Server/Data Center and account ID for Jira Cloud. Current user is added
+// if user is empty.
+// https://docs.atlassian.com/software/jira/docs/api/REST/9.16.0/#api/2/issue-addWatcher
+func (api *API) AddWatcher(issueIDOrKey, user string) error {
+	var body interface{}
+
+	if user != "" {
+		body = user
+	}
+
+	statusCode, err := api.doRequest(
+		"POST", "/rest/api/2/issue/"+issueIDOrKey+"/watchers",
+		EmptyParameters{}, nil, body, false,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	switch statusCode {
+	case 200, 204:
+		return nil
+	case 400:
+		return ErrInvalidInput
+	case 401:
+		return ErrNoAuth
+	case 403:
+		return ErrNoPerms
+	case 404:
+		return ErrNoContent
+	default:
+		return makeUnknownError(statusCode)
+	}
+}
+
+// RemoveWatcher removes a user from an issue's watcher list. User is username
+// for Jira Server/Data Center and account ID for Jira Cloud.
+// https://docs.atlassian.com/software/jira/docs/api/REST/9.16.0/#api/2/issue-removeWatcher
+func (api *API) RemoveWatcher(issueIDOrKey, user string) error {
+	if user == "" {
+		return ErrInvalidInput
+	}
+
+	params := UserParams{Username: user}
+
+	if api.isCloud {
+		params = UserParams{AccountID: user}
+	}
+
+	statusCode, err := api.doRequest(
+		"DELETE", "/rest/api/2/issue/"+issueIDOrKey+"/watchers",
+		params, nil, nil, false,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	switch statusCode {
+	case 200, 204:
+		return nil
+	case 400:
+		return ErrInvalidInput
+	case 401:
+		return ErrNoAuth
+	case 403:
+		return ErrNoPerms
+	case 404:
+		return ErrNoContent
+	default:
+		return makeUnknownError(statusCode)
+	}
+}
+
+// EnsureWatchers makes the issue's watcher list match the given list of users
+// (usernames for Jira Server/Data Center and account IDs for Jira Cloud). Only
+// missing watchers are added and only unwanted watchers are removed.
+func (api *API) EnsureWatchers(issueIDOrKey string, users []string) error {
+	info, err := api.GetIssueWatchers(issueIDOrKey)
+
+	if err != nil {
+		return err
+	}
+
+	current := make(map[string]bool)
+
+	for _, watcher := range info.Watchers {
+		current[api.getUserID(watcher)] = true
+	}
+
+	desired := make(map[string]bool)
+
+	for _, user := range users {
+		if user == "" || desired[user] {
+			continue
+		}
+
+		desired[user] = true
+
+		if !current[user] {
+			err = api.AddWatcher(issueIDOrKey, user)
+
+			if err != nil {
+				return fmt.Errorf("Can't add watcher %q: %w", user, err)
+			}
+		}
+	}
+
+	for _, watcher := range info.Watchers {
+		user := api.getUserID(watcher)
+
+		if !desired[user] {
+			err = api.RemoveWatcher(issueIDOrKey, user)
+
+			if err != nil {
+				return fmt.Errorf("Can't remove watcher %q: %w", user, err)
+			}
+		}
+	}
+
+	return nil
+}
+
 // GetIssueWorklogs returns all work logs for an issue
-// https://docs.atlassian.com/software/jira/docs/api/REST/6.4.13/#d2e4232
+// https://docs.atlassian.com/software/jira/docs/api/REST/9.16.0/#api/2/issue-getIssueWorklog
 func (api *API) GetIssueWorklogs(issueIDOrKey string) (*WorklogCollection, error) {
 	result := &WorklogCollection{}
 	statusCode, err := api.doRequest(
@@ -594,8 +975,14 @@ func (api *API) GetIssueWorklog(issueIDOrKey, worklogID string) (*Worklog, error
 // the available projects, issue types and fields, including field types
 // and whether or not those fields are required. Projects will not be returned
 // if the user does not have permission to create issues in that project.
+// Endpoint was removed in Jira 9.0, so for newer versions data is fetched using
+// paginated endpoints (legacy endpoint is used if Jira version can't be detected).
 // https://docs.atlassian.com/software/jira/docs/api/REST/6.4.13/#d2e4330
 func (api *API) GetCreateMeta(params CreateMetaParams) ([]*Project, error) {
+	if isSupported, err := api.supports(CAP_LEGACY_CREATE_META); err == nil && !isSupported {
+		return api.getPaginatedCreateMeta(params)
+	}
+
 	result := &struct {
 		Projects []*Project `json:"projects"`
 	}{}
@@ -620,6 +1007,75 @@ func (api *API) GetCreateMeta(params CreateMetaParams) ([]*Project, error) {
 	}
 }
 
+// GetCreateMetaIssueTypes returns issue types which can be used for creating issues
+// in given project (Jira 8.4+, ErrUnsupported is returned for older versions)
+// https://docs.atlassian.com/software/jira/docs/api/REST/9.16.0/#issue-getCreateIssueMetaProjectIssueTypes
+func (api *API) GetCreateMetaIssueTypes(projectIDOrKey string, params CreateMetaPageParams) (*CreateMetaIssueTypeCollection, error) {
+	if isSupported, err := api.supports(CAP_PAGINATED_CREATE_META); err == nil && !isSupported {
+		return nil, ErrUnsupported
+	}
+
+	result := &CreateMetaIssueTypeCollection{}
+	statusCode, err := api.doRequest(
+		"GET", "/rest/api/2/issue/createmeta/"+projectIDOrKey+"/issuetypes",
+		params, result, nil, true,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch statusCode {
+	case 200:
+		return result, nil
+	case 400:
+		return nil, ErrInvalidInput
+	case 401:
+		return nil, ErrNoAuth
+	case 403:
+		return nil, ErrNoPerms
+	case 404:
+		return nil, ErrNoContent
+	default:
+		return nil, makeUnknownError(statusCode)
+	}
+}
+
+// GetCreateMetaFields returns fields which can be used for creating issues with
+// given type in given project (Jira 8.4+, ErrUnsupported is returned for older
+// versions)
+// https://docs.atlassian.com/software/jira/docs/api/REST/9.16.0/#issue-getCreateIssueMetaProjectIssueTypeFields
+func (api *API) GetCreateMetaFields(projectIDOrKey, issueTypeID string, params CreateMetaPageParams) (*CreateMetaFieldCollection, error) {
+	if isSupported, err := api.supports(CAP_PAGINATED_CREATE_META); err == nil && !isSupported {
+		return nil, ErrUnsupported
+	}
+
+	result := &CreateMetaFieldCollection{}
+	statusCode, err := api.doRequest(
+		"GET", "/rest/api/2/issue/createmeta/"+projectIDOrKey+"/issuetypes/"+issueTypeID,
+		params, result, nil, true,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch statusCode {
+	case 200:
+		return result, nil
+	case 400:
+		return nil, ErrInvalidInput
+	case 401:
+		return nil, ErrNoAuth
+	case 403:
+		return nil, ErrNoPerms
+	case 404:
+		return nil, ErrNoContent
+	default:
+		return nil, makeUnknownError(statusCode)
+	}
+}
+
 // IssuePicker returns suggested issues which match the auto-completion query for the
 // user which executes this request. This REST method will check the user's history
 // and the user's browsing context and select this issues, which match the query.
@@ -756,11 +1212,160 @@ func (api *API) GetIssueLinkType(linkTypeID string) (*LinkType, error) {
 	case 200:
 		return result, nil
 	case 401:
-		return nil, ErrNoAuth
+		return nil, ErrNoAuth
+	case 404:
+		return nil, ErrNoContent
+	default:
+		return nil, makeUnknownError(statusCode)
+	}
+}
+
+// CreateIssueLink creates an issue link between two issues. Link type can be set
+// by name (e.g. "Blocks"). Comment is optional.
+// https://docs.atlassian.com/software/jira/docs/api/REST/6.4.13/#d2e3295
+func (api *API) CreateIssueLink(linkType, inwardIssue, outwardIssue, comment string) error {
+	statusCode, err := api.doRequest(
+		"POST", "/rest/api/2/issueLink",
+		EmptyParameters{}, nil, api.makeIssueLinkRequest(linkType, inwardIssue, outwardIssue, comment), true,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	switch statusCode {
+	case 200, 201:
+		return nil
+	case 400:
+		return ErrInvalidInput
+	case 401:
+		return ErrNoAuth
+	case 403:
+		return ErrNoPerms
+	case 404:
+		return ErrNoContent
+	case 500:
+		return ErrGenResponse
+	default:
+		return makeUnknownError(statusCode)
+	}
+}
+
+// DeleteIssueLink deletes an issue link with the specified id
+// https://docs.atlassian.com/software/jira/docs/api/REST/6.4.13/#d2e3352
+func (api *API) DeleteIssueLink(linkID string) error {
+	statusCode, err := api.doRequest(
+		"DELETE", "/rest/api/2/issueLink/"+linkID,
+		EmptyParameters{}, nil, nil, false,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	switch statusCode {
+	case 200, 204:
+		return nil
+	case 400:
+		return ErrInvalidInput
+	case 401:
+		return ErrNoAuth
+	case 403:
+		return ErrNoPerms
+	case 404:
+		return ErrNoContent
+	default:
+		return makeUnknownError(statusCode)
+	}
+}
+
+// CreateIssueLinkType creates a new issue link type. Admin permission will be required.
+// https://docs.atlassian.com/software/jira/docs/api/REST/6.4.13/#d2e4963
+func (api *API) CreateIssueLinkType(linkType *LinkType) (*LinkType, error) {
+	result := &LinkType{}
+	statusCode, err := api.doRequest(
+		"POST", "/rest/api/2/issueLinkType",
+		EmptyParameters{}, result, makeLinkTypeRequest(linkType), true,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch statusCode {
+	case 200, 201:
+		return result, nil
+	case 400:
+		return nil, ErrInvalidInput
+	case 401:
+		return nil, ErrNoAuth
+	case 403:
+		return nil, ErrNoPerms
+	case 404:
+		return nil, ErrNoContent
+	default:
+		return nil, makeUnknownError(statusCode)
+	}
+}
+
+// UpdateIssueLinkType updates the issue link type with ID from given struct. Admin
+// permission will be required.
+// https://docs.atlassian.com/software/jira/docs/api/REST/6.4.13/#d2e5036
+func (api *API) UpdateIssueLinkType(linkType *LinkType) (*LinkType, error) {
+	if linkType.ID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	result := &LinkType{}
+	statusCode, err := api.doRequest(
+		"PUT", "/rest/api/2/issueLinkType/"+linkType.ID,
+		EmptyParameters{}, result, makeLinkTypeRequest(linkType), true,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch statusCode {
+	case 200:
+		return result, nil
+	case 400:
+		return nil, ErrInvalidInput
+	case 401:
+		return nil, ErrNoAuth
+	case 403:
+		return nil, ErrNoPerms
+	case 404:
+		return nil, ErrNoContent
+	default:
+		return nil, makeUnknownError(statusCode)
+	}
+}
+
+// DeleteIssueLinkType deletes the issue link type with given id. Admin permission
+// will be required.
+// https://docs.atlassian.com/software/jira/docs/api/REST/6.4.13/#d2e5062
+func (api *API) DeleteIssueLinkType(linkTypeID string) error {
+	statusCode, err := api.doRequest(
+		"DELETE", "/rest/api/2/issueLinkType/"+linkTypeID,
+		EmptyParameters{}, nil, nil, false,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	switch statusCode {
+	case 200, 204:
+		return nil
+	case 401:
+		return ErrNoAuth
+	case 403:
+		return ErrNoPerms
 	case 404:
-		return nil, ErrNoContent
+		return ErrNoContent
 	default:
-		return nil, makeUnknownError(statusCode)
+		return makeUnknownError(statusCode)
 	}
 }
 
@@ -1531,7 +2136,7 @@ func (api *API) GetStatusCategory(caregoryIDOrName string) (*StatusCategory, err
 // provided. You can page through users list by using indexes in expand param. For example
 // to get users from index 10 to index 15 use "users[10:15]" expand value. This will
 // return 6 users (if there are at least 16 users in this group). Indexes are 0-based
-// and inclusive.
+// and inclusive. Not available in Jira Cloud.
 // https://docs.atlassian.com/software/jira/docs/api/REST/6.4.13/#d2e2890
 func (api *API) GetGroup(params GroupParams) (*Group, error) {
 	result := &Group{}
@@ -1563,6 +2168,10 @@ func (api *API) GetGroup(params GroupParams) (*Group, error) {
 // GetUser returns a user
 // https://docs.atlassian.com/software/jira/docs/api/REST/6.4.13/#d2e1869
 func (api *API) GetUser(params UserParams) (*User, error) {
+	if api.isCloud && params.AccountID == "" {
+		return nil, ErrEmptyAccountID
+	}
+
 	result := &User{}
 	statusCode, err := api.doRequest(
 		"GET", "/rest/api/2/user",
@@ -1614,11 +2223,12 @@ func (api *API) GetUserAvatars(username string) (*Avatars, error) {
 
 // GetUserColumns returns the default columns for the given user. Admin permission
 // will be required to get columns for a user other than the currently logged in user.
+// For Jira Cloud account ID must be used instead of username.
 // https://docs.atlassian.com/software/jira/docs/api/REST/6.4.13/#d2e2400
 func (api *API) GetUserColumns(username string) ([]*Column, error) {
 	result := []*Column{}
 	statusCode, err := api.doRequest(
-		"GET", "/rest/api/2/user/columns?username="+username,
+		"GET", "/rest/api/2/user/columns?"+api.getUserParamName()+"="+username,
 		EmptyParameters{}, &result, nil, true,
 	)
 
@@ -1745,14 +2355,13 @@ func (api *API) GroupUserPicker(params GroupUserPickerParams) (*GroupUserPickerR
 	}
 }
 
-// Search searches for issues using JQL
+// Search searches for issues using JQL. Jira Cloud uses enhanced search with token
+// pagination, so NextPageToken must be used instead of StartAt, and results
+// don't contain total number of issues.
 // https://docs.atlassian.com/software/jira/docs/api/REST/6.4.13/#d2e1528
+// https://developer.atlassian.com/cloud/jira/platform/rest/v3/api-group-issue-search/#api-rest-api-3-search-jql-get
 func (api *API) Search(params SearchParams) (*SearchResults, error) {
-	result := &SearchResults{}
-	statusCode, err := api.doRequest(
-		"GET", "/rest/api/2/search",
-		params, result, nil, true,
-	)
+	result, statusCode, err := api.search(params)
 
 	if err != nil {
 		return nil, err
@@ -2120,6 +2729,181 @@ func (api *API) GetWorkflowSchemeWorkflows(schemeID string, returnDraftIfExists
 	}
 }
 
+// GetWebhooks returns all registered webhooks. Admin permission will be required.
+// https://developer.atlassian.com/server/jira/platform/webhooks/#registering-a-webhook-via-the-jira-rest-api
+func (api *API) GetWebhooks() ([]*Webhook, error) {
+	result := []*Webhook{}
+	statusCode, err := api.doRequest(
+		"GET", "/rest/webhooks/1.0/webhook",
+		EmptyParameters{}, &result, nil, false,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch statusCode {
+	case 200:
+		for _, webhook := range result {
+			webhook.ID = extractWebhookID(webhook.Self)
+		}
+
+		return result, nil
+	case 401:
+		return nil, ErrNoAuth
+	case 403:
+		return nil, ErrNoPerms
+	default:
+		return nil, makeUnknownError(statusCode)
+	}
+}
+
+// GetWebhook returns webhook with given ID. Admin permission will be required.
+// https://developer.atlassian.com/server/jira/platform/webhooks/#registering-a-webhook-via-the-jira-rest-api
+func (api *API) GetWebhook(webhookID string) (*Webhook, error) {
+	result := &Webhook{}
+	statusCode, err := api.doRequest(
+		"GET", "/rest/webhooks/1.0/webhook/"+webhookID,
+		EmptyParameters{}, result, nil, false,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch statusCode {
+	case 200:
+		result.ID = extractWebhookID(result.Self)
+		return result, nil
+	case 401:
+		return nil, ErrNoAuth
+	case 403:
+		return nil, ErrNoPerms
+	case 404:
+		return nil, ErrNoContent
+	default:
+		return nil, makeUnknownError(statusCode)
+	}
+}
+
+// CreateWebhook registers new webhook. Admin permission will be required.
+// https://developer.atlassian.com/server/jira/platform/webhooks/#registering-a-webhook-via-the-jira-rest-api
+func (api *API) CreateWebhook(webhook *Webhook) (*Webhook, error) {
+	result := &Webhook{}
+	statusCode, err := api.doRequest(
+		"POST", "/rest/webhooks/1.0/webhook",
+		EmptyParameters{}, result, makeWebhookRequest(webhook), true,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch statusCode {
+	case 200, 201:
+		result.ID = extractWebhookID(result.Self)
+		return result, nil
+	case 400:
+		return nil, ErrInvalidInput
+	case 401:
+		return nil, ErrNoAuth
+	case 403:
+		return nil, ErrNoPerms
+	default:
+		return nil, makeUnknownError(statusCode)
+	}
+}
+
+// UpdateWebhook updates webhook with given ID. Admin permission will be required.
+// https://developer.atlassian.com/server/jira/platform/webhooks/#registering-a-webhook-via-the-jira-rest-api
+func (api *API) UpdateWebhook(webhookID string, webhook *Webhook) (*Webhook, error) {
+	result := &Webhook{}
+	statusCode, err := api.doRequest(
+		"PUT", "/rest/webhooks/1.0/webhook/"+webhookID,
+		EmptyParameters{}, result, makeWebhookRequest(webhook), true,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch statusCode {
+	case 200:
+		result.ID = extractWebhookID(result.Self)
+		return result, nil
+	case 400:
+		return nil, ErrInvalidInput
+	case 401:
+		return nil, ErrNoAuth
+	case 403:
+		return nil, ErrNoPerms
+	case 404:
+		return nil, ErrNoContent
+	default:
+		return nil, makeUnknownError(statusCode)
+	}
+}
+
+// DeleteWebhook removes webhook with given ID. Admin permission will be required.
+// https://developer.atlassian.com/server/jira/platform/webhooks/#registering-a-webhook-via-the-jira-rest-api
+func (api *API) DeleteWebhook(webhookID string) error {
+	statusCode, err := api.doRequest(
+		"DELETE", "/rest/webhooks/1.0/webhook/"+webhookID,
+		EmptyParameters{}, nil, nil, false,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	switch statusCode {
+	case 200, 204:
+		return nil
+	case 401:
+		return ErrNoAuth
+	case 403:
+		return ErrNoPerms
+	case 404:
+		return ErrNoContent
+	default:
+		return makeUnknownError(statusCode)
+	}
+}
+
+// EnableWebhook enables webhook with given ID
+func (api *API) EnableWebhook(webhookID string) error {
+	return api.setWebhookEnabled(webhookID, true)
+}
+
+// DisableWebhook disables webhook with given ID
+func (api *API) DisableWebhook(webhookID string) error {
+	return api.setWebhookEnabled(webhookID, false)
+}
+
+// EnsureWebhook creates webhook or updates existing webhook with the same URL
+// if its configuration differs from the given one
+func (api *API) EnsureWebhook(webhook *Webhook) (*Webhook, error) {
+	webhooks, err := api.GetWebhooks()
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, existing := range webhooks {
+		if existing.URL != webhook.URL {
+			continue
+		}
+
+		if isWebhookEqual(existing, webhook) {
+			return existing, nil
+		}
+
+		return api.UpdateWebhook(existing.ID, webhook)
+	}
+
+	return api.CreateWebhook(webhook)
+}
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // getEntityProperties returns all entity (issue/project) properties
@@ -2226,57 +3010,223 @@ func (api *API) deleteEntityProperty(url, propKey string) error {
 	}
 }
 
+// search sends search request and returns results with response status code
+func (api *API) search(params SearchParams) (*SearchResults, int, error) {
+	uri := "/rest/api/2/search"
+
+	if api.isCloud {
+		// Enhanced search doesn't support query validation toggle and returns
+		// only issues IDs if fields are not set
+		if params.DisableQueryValidation {
+			return nil, 0, ErrInvalidInput
+		}
+
+		if len(params.Fields) == 0 {
+			params.Fields = []string{"*navigable"}
+		}
+
+		uri = "/rest/api/2/search/jql"
+	}
+
+	result := &SearchResults{}
+	statusCode, err := api.doRequest(
+		"GET", uri,
+		params, result, nil, true,
+	)
+
+	return result, statusCode, err
+}
+
+// setWebhookEnabled enables or disables webhook
+func (api *API) setWebhookEnabled(webhookID string, enabled bool) error {
+	webhook, err := api.GetWebhook(webhookID)
+
+	if err != nil {
+		return err
+	}
+
+	if webhook.IsEnabled == enabled {
+		return nil
+	}
+
+	webhook.IsEnabled = enabled
+
+	_, err = api.UpdateWebhook(webhookID, webhook)
+
+	return err
+}
+
+// makeIssueLinkRequest creates request for creating issue link
+func (api *API) makeIssueLinkRequest(linkType, inwardIssue, outwardIssue, comment string) any {
+	type typeRef struct {
+		Name string `json:"name"`
+	}
+
+	type issueRef struct {
+		Key string `json:"key"`
+	}
+
+	request := &struct {
+		Type         *typeRef  `json:"type"`
+		InwardIssue  *issueRef `json:"inwardIssue"`
+		OutwardIssue *issueRef `json:"outwardIssue"`
+		Comment      any       `json:"comment,omitempty"`
+	}{
+		Type:         &typeRef{linkType},
+		InwardIssue:  &issueRef{inwardIssue},
+		OutwardIssue: &issueRef{outwardIssue},
+	}
+
+	switch {
+	case comment == "":
+		// no comment
+	case api.isCloud:
+		// Jira Cloud requires comment body in ADF
+		request.Comment = map[string]any{"body": NewADF(ADFParagraph(ADFText(comment)))}
+	default:
+		request.Comment = map[string]any{"body": comment}
+	}
+
+	return request
+}
+
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 // codebeat:disable[ARITY]
 
 // doRequest create and execute request
 func (api *API) doRequest(method, uri string, params Parameters, result, body interface{}, decodeError bool) (int, error) {
-	req := api.acquireRequest(method, uri, params)
-	resp := fasthttp.AcquireResponse()
+	if api.instrumentation == nil {
+		return api.processRequest(method, uri, params, result, body, decodeError)
+	}
 
-	defer fasthttp.ReleaseRequest(req)
-	defer fasthttp.ReleaseResponse(resp)
+	return api.doInstrumentedRequest(method, uri, func() (int, error) {
+		return api.processRequest(method, uri, params, result, body, decodeError)
+	})
+}
+
+// processRequest sends request and decodes response
+func (api *API) processRequest(method, uri string, params Parameters, result, body interface{}, decodeError bool) (int, error) {
+	if api.isCloud && !isCloudSupported(method, uri, params) {
+		return -1, ErrUnsupported
+	}
+
+	var bodyData []byte
+	var err error
 
 	if body != nil {
-		bodyData, err := json.Marshal(body)
+		bodyData, err = json.Marshal(body)
 
 		if err != nil {
 			return -1, err
 		}
-
-		req.SetBody(bodyData)
 	}
 
-	err := api.Client.Do(req, resp)
+	var statusCode int
+	var respData []byte
+
+	if ttl := api.cache.getTTL(method, uri); ttl > 0 {
+		statusCode, respData, err = api.sendCachedRequest(uri, params, ttl)
+	} else {
+		statusCode, respData, err = api.sendRequest(method, uri, params, bodyData)
+	}
 
 	if err != nil {
 		return -1, err
 	}
 
-	statusCode := resp.StatusCode()
-
-	if statusCode != 200 && decodeError {
-		return statusCode, decodeInternalError(resp.Body())
+	// Create methods return 201 with created entity, so any successful response
+	// must be decoded as result instead of error
+	if (statusCode < 200 || statusCode > 299) && decodeError {
+		return statusCode, decodeInternalError(respData)
 	}
 
 	if result == nil {
 		return statusCode, nil
 	}
 
-	err = json.Unmarshal(resp.Body(), result)
+	err = json.Unmarshal(respData, result)
 
 	return statusCode, err
 }
 
 // codebeat:enable[ARITY]
 
+// sendRequest sends request and refreshes auth credentials if required
+func (api *API) sendRequest(method, uri string, params Parameters, body []byte) (int, []byte, error) {
+	refresher, isRefreshable := api.auth.(AuthRefresher)
+
+	if isRefreshable && refresher.IsExpired() {
+		err := refresher.Refresh(api)
+
+		if err != nil {
+			return -1, nil, err
+		}
+	}
+
+	authState := getAuthState(api.auth)
+	resp, err := api.executeRequest(method, uri, params, body)
+
+	// Credentials can be revoked before expiration time, so we try to refresh
+	// them and repeat request once. If credentials were already refreshed by
+	// concurrent request, we just repeat request with new credentials.
+	if err == nil && resp.StatusCode == 401 && isRefreshable &&
+		(getAuthState(api.auth) != authState || refresher.Refresh(api) == nil) {
+		resp, err = api.executeRequest(method, uri, params, body)
+	}
+
+	if err != nil {
+		return -1, nil, err
+	}
+
+	return resp.StatusCode, resp.Body, nil
+}
+
+// executeRequest executes request and retries it if it was throttled
+func (api *API) executeRequest(method, uri string, params Parameters, body []byte) (*Response, error) {
+	limiter := api.limiter
+
+	if limiter == nil {
+		return api.executeRawRequest(method, uri, params, body, 0)
+	}
+
+	for attempt := 0; ; attempt++ {
+		limiter.wait(method)
+
+		resp, err := api.executeRawRequest(method, uri, params, body, attempt)
+
+		if err != nil || resp.StatusCode != 429 || attempt >= limiter.maxRetries {
+			return resp, err
+		}
+
+		limiter.pause(parseRetryAfter(string(resp.Header.Peek("Retry-After")), attempt))
+	}
+}
+
+// executeRawRequest executes request through middlewares chain
+func (api *API) executeRawRequest(method, uri string, params Parameters, body []byte, attempt int) (*Response, error) {
+	req := api.acquireRequest(method, uri, params)
+	defer fasthttp.ReleaseRequest(req)
+
+	if body != nil {
+		req.SetBody(body)
+	}
+
+	return api.getHandler()(&Request{
+		Params:  params,
+		HTTP:    req,
+		Method:  method,
+		URI:     uri,
+		Attempt: attempt,
+	})
+}
+
 // acquireRequest acquire new request with given params
 func (api *API) acquireRequest(method, uri string, params Parameters) *fasthttp.Request {
 	req := fasthttp.AcquireRequest()
 	query := params.ToQuery()
 
-	req.SetRequestURI(api.url + uri)
+	req.SetRequestURI(api.url + api.getRequestURI(uri))
 
 	// Set query if params can be encoded as query
 	if query != "" {
@@ -2288,9 +3238,21 @@ func (api *API) acquireRequest(method, uri string, params Parameters) *fasthttp.
 		req.Header.SetMethod(method)
 	}
 
-	// Set authorization header
-	if api.auth != "" {
-		req.Header.Add("Authorization", api.auth)
+	// Set authorization header or cookie
+	if api.auth != nil {
+		authData := api.auth.Encode()
+
+		if authData != "" {
+			req.Header.Add("Authorization", authData)
+		}
+
+		if c, ok := api.auth.(AuthCookie); ok {
+			name, value := c.Cookie()
+
+			if value != "" {
+				req.Header.SetCookie(name, value)
+			}
+		}
 	}
 
 	return req
@@ -2310,6 +3272,103 @@ func decodeInternalError(data []byte) error {
 	return ec.Error()
 }
 
+// makeWebhookRequest creates request body for creating/updating webhook
+func makeWebhookRequest(webhook *Webhook) any {
+	filters := &WebhookFilters{}
+
+	if webhook.Filters != nil {
+		filters = webhook.Filters
+	}
+
+	return &struct {
+		Filters     *WebhookFilters `json:"filters"`
+		Name        string          `json:"name"`
+		URL         string          `json:"url"`
+		Events      []string        `json:"events"`
+		ExcludeBody bool            `json:"excludeBody"`
+		Enabled     bool            `json:"enabled"`
+	}{
+		Filters:     filters,
+		Name:        webhook.Name,
+		URL:         webhook.URL,
+		Events:      webhook.Events,
+		ExcludeBody: webhook.IsBodyExcluded,
+		Enabled:     webhook.IsEnabled,
+	}
+}
+
+// makeLinkTypeRequest creates request for creating or updating issue link type
+func makeLinkTypeRequest(linkType *LinkType) any {
+	return &struct {
+		Name    string `json:"name"`
+		Inward  string `json:"inward"`
+		Outward string `json:"outward"`
+	}{
+		Name:    linkType.Name,
+		Inward:  linkType.Inward,
+		Outward: linkType.Outward,
+	}
+}
+
+// makeRemoteLinkRequest creates request for creating or updating remote link
+func makeRemoteLinkRequest(link *RemoteLink) any {
+	return &struct {
+		Application  *RemoteLinkApp  `json:"application,omitempty"`
+		Info         *RemoteLinkInfo `json:"object"`
+		GlobalID     string          `json:"globalId,omitempty"`
+		Relationship string          `json:"relationship,omitempty"`
+	}{
+		Application:  link.Application,
+		Info:         link.Info,
+		GlobalID:     link.GlobalID,
+		Relationship: link.Relationship,
+	}
+}
+
+// extractWebhookID extracts webhook ID from webhook URL
+func extractWebhookID(self string) string {
+	self = strings.TrimRight(self, "/")
+
+	if !strings.Contains(self, "/") {
+		return self
+	}
+
+	return self[strings.LastIndex(self, "/")+1:]
+}
+
+// isWebhookEqual returns true if webhooks have the same configuration
+func isWebhookEqual(w1, w2 *Webhook) bool {
+	var jql1, jql2 string
+
+	if w1.Filters != nil {
+		jql1 = w1.Filters.JQL
+	}
+
+	if w2.Filters != nil {
+		jql2 = w2.Filters.JQL
+	}
+
+	if w1.Name != w2.Name || w1.URL != w2.URL || jql1 != jql2 ||
+		w1.IsBodyExcluded != w2.IsBodyExcluded || w1.IsEnabled != w2.IsEnabled ||
+		len(w1.Events) != len(w2.Events) {
+		return false
+	}
+
+	events := map[string]bool{}
+
+	for _, event := range w1.Events {
+		events[event] = true
+	}
+
+	for _, event := range w2.Events {
+		if !events[event] {
+			return false
+		}
+	}
+
+	return true
+}
+
 // getUserAgent generate user-agent string for client
 func getUserAgent(app, version string) string {
 	if app != "" && version != "" {
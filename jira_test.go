@@ -3,6 +3,22 @@ package jira
 // ////////////////////////////////////////////////////////////////////////////////// //
 
 import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -97,9 +113,1601 @@ func (s *JiraSuite) TestAuthMethods(c *C) {
 	t1 := AuthToken{"TESTVYhExHzKbHzNPCMRmviasXJoUaATysUimxwiWmkr"}
 	t2 := AuthToken{""}
 	t3 := AuthToken{"TEST"}
+	t4 := AuthToken{"TEST 1234\n"}
 
 	c.Assert(t1.Encode(), Equals, "Bearer TESTVYhExHzKbHzNPCMRmviasXJoUaATysUimxwiWmkr")
 	c.Assert(t1.Validate(), IsNil)
 	c.Assert(t2.Validate(), DeepEquals, ErrEmptyToken)
-	c.Assert(t3.Validate(), DeepEquals, ErrTokenWrongLength)
+	c.Assert(t3.Validate(), IsNil)
+	c.Assert(t4.Validate(), DeepEquals, ErrTokenWrongSymbols)
+
+	c.Assert(t1.ValidateWith(ValidateTokenStrict), IsNil)
+	c.Assert(t3.ValidateWith(ValidateTokenStrict), DeepEquals, ErrTokenWrongLength)
+	c.Assert(t3.ValidateWith(nil), IsNil)
+
+	st1 := AuthTokenStrict{"TESTVYhExHzKbHzNPCMRmviasXJoUaATysUimxwiWmkr"}
+	st2 := AuthTokenStrict{"TEST"}
+
+	c.Assert(st1.Encode(), Equals, "Bearer TESTVYhExHzKbHzNPCMRmviasXJoUaATysUimxwiWmkr")
+	c.Assert(st1.Validate(), IsNil)
+	c.Assert(st2.Validate(), DeepEquals, ErrTokenWrongLength)
+
+	ct1 := AuthCloudToken{"john@domain.com", "ATATT3xFfGF0T4"}
+	ct2 := AuthCloudToken{"", "ATATT3xFfGF0T4"}
+	ct3 := AuthCloudToken{"john@domain.com", ""}
+
+	c.Assert(ct1.Encode(), Equals, "Basic am9obkBkb21haW4uY29tOkFUQVRUM3hGZkdGMFQ0")
+	c.Assert(ct1.Validate(), IsNil)
+	c.Assert(ct2.Validate(), DeepEquals, ErrEmptyEmail)
+	c.Assert(ct3.Validate(), DeepEquals, ErrEmptyToken)
+}
+
+func (s *JiraSuite) TestOAuthRefresh(c *C) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/oauth2/latest/token":
+			r.ParseForm()
+			if r.PostForm.Get("refresh_token") != "REFRESH1" || r.PostForm.Get("client_id") != "CLIENT" {
+				w.WriteHeader(400)
+				w.Write([]byte(`{"error":"invalid_grant"}`))
+				return
+			}
+			w.Write([]byte(`{"access_token":"ACCESS2","refresh_token":"REFRESH2","expires_in":3600}`))
+		case "/rest/api/2/myself":
+			if r.Header.Get("Authorization") != "Bearer ACCESS2" {
+				w.WriteHeader(401)
+				return
+			}
+			w.Write([]byte(`{"name":"john"}`))
+		}
+	}))
+
+	defer srv.Close()
+
+	var saved OAuthToken
+
+	auth := &AuthOAuth{
+		ClientID:      "CLIENT",
+		Token:         OAuthToken{AccessToken: "ACCESS1", RefreshToken: "REFRESH1"},
+		OnTokenUpdate: func(t OAuthToken) { saved = t },
+	}
+
+	c.Assert((&AuthOAuth{}).Validate(), Equals, ErrEmptyClientID)
+	c.Assert((&AuthOAuth{ClientID: "CLIENT"}).Validate(), Equals, ErrEmptyToken)
+	c.Assert(auth.IsExpired(), Equals, false)
+
+	api, err := NewAPI(srv.URL, auth)
+	c.Assert(err, IsNil)
+
+	user, err := api.GetMyself()
+	c.Assert(err, IsNil)
+	c.Assert(user.Name, Equals, "john")
+	c.Assert(auth.Encode(), Equals, "Bearer ACCESS2")
+	c.Assert(saved.RefreshToken, Equals, "REFRESH2")
+	c.Assert(saved.Expiry.After(time.Now()), Equals, true)
+
+	_, err = api.GetMyself()
+	c.Assert(err, IsNil)
+
+	err = auth.Refresh(api)
+	c.Assert(err, ErrorMatches, "Can't obtain OAuth token: invalid_grant")
+}
+
+func (s *JiraSuite) TestOAuthConcurrentRefresh(c *C) {
+	var refreshes, updates int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/oauth2/latest/token":
+			r.ParseForm()
+			if r.PostForm.Get("refresh_token") != "REFRESH1" || atomic.AddInt32(&refreshes, 1) != 1 {
+				w.WriteHeader(400)
+				w.Write([]byte(`{"error":"invalid_grant"}`))
+				return
+			}
+			time.Sleep(20 * time.Millisecond)
+			w.Write([]byte(`{"access_token":"ACCESS2","refresh_token":"REFRESH2","expires_in":3600}`))
+		case "/rest/api/2/myself":
+			if r.Header.Get("Authorization") != "Bearer ACCESS2" {
+				w.WriteHeader(401)
+				return
+			}
+			w.Write([]byte(`{"name":"john"}`))
+		}
+	}))
+
+	defer srv.Close()
+
+	for _, token := range []OAuthToken{
+		{AccessToken: "ACCESS1", RefreshToken: "REFRESH1"},
+		{AccessToken: "ACCESS1", RefreshToken: "REFRESH1", Expiry: time.Now().Add(-time.Minute)},
+	} {
+		refreshes, updates = 0, 0
+
+		auth := &AuthOAuth{
+			ClientID:      "CLIENT",
+			Token:         token,
+			OnTokenUpdate: func(t OAuthToken) { atomic.AddInt32(&updates, 1) },
+		}
+
+		api, err := NewAPI(srv.URL, auth)
+		c.Assert(err, IsNil)
+
+		var wg sync.WaitGroup
+
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := api.GetMyself()
+				c.Check(err, IsNil)
+			}()
+		}
+
+		wg.Wait()
+
+		c.Assert(atomic.LoadInt32(&refreshes), Equals, int32(1))
+		c.Assert(atomic.LoadInt32(&updates), Equals, int32(1))
+	}
+}
+
+func (s *JiraSuite) TestSessionAuth(c *C) {
+	var logins, logouts int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/rest/auth/1/session" && r.Method == "POST":
+			login := atomic.AddInt32(&logins, 1)
+			time.Sleep(10 * time.Millisecond)
+			w.Write([]byte(`{"session":{"name":"JSESSIONID","value":"SESSION` + string(rune('0'+login)) + `"}}`))
+		case r.URL.Path == "/rest/auth/1/session" && r.Method == "DELETE":
+			if atomic.AddInt32(&logouts, 1) == 1 {
+				w.WriteHeader(500)
+				return
+			}
+			w.WriteHeader(204)
+		case r.URL.Path == "/rest/api/2/myself":
+			login := atomic.LoadInt32(&logins)
+			cookie, err := r.Cookie("JSESSIONID")
+			if err != nil || login < 2 || cookie.Value != "SESSION"+string(rune('0'+login)) ||
+				r.Header.Get("Authorization") != "" {
+				w.WriteHeader(401)
+				return
+			}
+			w.Write([]byte(`{"name":"john"}`))
+		}
+	}))
+
+	defer srv.Close()
+
+	c.Assert((&AuthSession{Password: "Test1234!"}).Validate(), Equals, ErrEmptyUser)
+
+	auth := &AuthSession{User: "john", Password: "Test1234!"}
+	api, err := NewAPI(srv.URL, auth)
+	c.Assert(err, IsNil)
+
+	user, err := api.GetMyself()
+	c.Assert(err, IsNil)
+	c.Assert(user.Name, Equals, "john")
+	c.Assert(logins, Equals, int32(2))
+
+	// Stale session cookie must be replaced only once
+	auth.mu.Lock()
+	auth.cookieValue = "SESSION1"
+	auth.mu.Unlock()
+
+	var wg sync.WaitGroup
+
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := api.GetMyself()
+			c.Check(err, IsNil)
+		}()
+	}
+
+	wg.Wait()
+
+	c.Assert(atomic.LoadInt32(&logins), Equals, int32(3))
+
+	c.Assert(api.Close(), NotNil)
+	c.Assert(auth.IsExpired(), Equals, false)
+	c.Assert(api.Close(), IsNil)
+	c.Assert(logouts, Equals, int32(2))
+	c.Assert(auth.IsExpired(), Equals, true)
+}
+
+func (s *JiraSuite) TestCredentialsProviders(c *C) {
+	netrc := c.MkDir() + "/netrc"
+	err := os.WriteFile(netrc, []byte(
+		"machine example.com login bob password Test1234!\n"+
+			"machine jira.domain.com:8443\n  login john@domain.com\n  password ATATT3xFfGF0T4\n"+
+			"default login guest password guest\n",
+	), 0600)
+	c.Assert(err, IsNil)
+
+	creds, err := NetrcProvider{URL: "https://jira.domain.com:8443", File: netrc}.Credentials()
+	c.Assert(err, IsNil)
+	c.Assert(creds.Auth, DeepEquals, AuthCloudToken{"john@domain.com", "ATATT3xFfGF0T4"})
+
+	creds, err = NetrcProvider{URL: "https://example.com", File: netrc}.Credentials()
+	c.Assert(err, IsNil)
+	c.Assert(creds.Auth, DeepEquals, AuthBasic{"bob", "Test1234!"})
+
+	creds, err = NetrcProvider{URL: "https://unknown.com", File: netrc}.Credentials()
+	c.Assert(err, IsNil)
+	c.Assert(creds.Auth, DeepEquals, AuthBasic{"guest", "guest"})
+
+	os.Chmod(netrc, 0644)
+	_, err = NetrcProvider{URL: "https://example.com", File: netrc}.Credentials()
+	c.Assert(err, IsNil)
+
+	os.Unsetenv(ENV_URL)
+
+	_, err = NetrcProvider{File: netrc}.Credentials()
+	c.Assert(err, Equals, ErrNoCredentials)
+	_, err = DefaultProvider().Credentials()
+	c.Assert(err, Equals, ErrNoCredentials)
+
+	os.Setenv(ENV_URL, "https://jira.domain.com")
+	os.Setenv(ENV_USER, "")
+	os.Setenv(ENV_TOKEN, "")
+	os.Setenv(ENV_PASSWORD, "")
+
+	_, err = EnvProvider{}.Credentials()
+	c.Assert(err, Equals, ErrNoCredentials)
+
+	tokenFile := c.MkDir() + "/token"
+	os.WriteFile(tokenFile, []byte("TESTVYhExHzKbHzNPCMRmviasXJoUaATysUimxwiWmkr\n"), 0644)
+
+	_, err = FileProvider{File: tokenFile}.Credentials()
+	c.Assert(err, ErrorMatches, ErrInsecureFile.Error()+".*")
+
+	os.Chmod(tokenFile, 0600)
+
+	creds, err = ChainProvider{EnvProvider{}, FileProvider{File: tokenFile}}.Credentials()
+	c.Assert(err, IsNil)
+	c.Assert(creds.URL, Equals, "https://jira.domain.com")
+	c.Assert(creds.Auth, DeepEquals, AuthToken{"TESTVYhExHzKbHzNPCMRmviasXJoUaATysUimxwiWmkr"})
+
+	os.Setenv(ENV_USER, "john")
+	os.Setenv(ENV_PASSWORD, "Test1234!")
+
+	creds, err = ChainProvider{EnvProvider{}, FileProvider{File: tokenFile}}.Credentials()
+	c.Assert(err, IsNil)
+	c.Assert(creds.Auth, DeepEquals, AuthBasic{"john", "Test1234!"})
+
+	for _, env := range []string{ENV_URL, ENV_USER, ENV_TOKEN, ENV_PASSWORD} {
+		os.Unsetenv(env)
+	}
+}
+
+func (s *JiraSuite) TestWithAuth(c *C) {
+	api, err := NewAPI("https://jira.domain.com", AuthBasic{"john", "Test1234!"})
+	c.Assert(err, IsNil)
+
+	_, err = api.WithAuth(nil)
+	c.Assert(err, Equals, ErrNilAuth)
+	_, err = api.WithAuth(AuthToken{})
+	c.Assert(err, Equals, ErrEmptyToken)
+
+	child, err := api.WithAuth(AuthToken{"TESTVYhExHzKbHzNPCMRmviasXJoUaATysUimxwiWmkr"})
+	c.Assert(err, IsNil)
+	c.Assert(child.Client, Equals, api.Client)
+	c.Assert(child.auth.Encode(), Equals, "Bearer TESTVYhExHzKbHzNPCMRmviasXJoUaATysUimxwiWmkr")
+	c.Assert(api.auth.Encode(), Equals, "Basic am9objpUZXN0MTIzNCE=")
+	c.Assert(child.Close(), IsNil)
+}
+
+func (s *JiraSuite) TestWebhookHandler(c *C) {
+	var updated, any int
+
+	h := NewWebhookHandler()
+
+	h.On(EVENT_ISSUE_UPDATED, func(e *WebhookEvent) error {
+		updated++
+		c.Assert(e.Issue.Key, Equals, "ABC-1")
+		c.Assert(e.Issue.Fields.Summary, Equals, "Test")
+		c.Assert(e.User.Name, Equals, "john")
+		c.Assert(e.Changelog.Items, HasLen, 1)
+		c.Assert(e.Changelog.Items[0].ToString, Equals, "In Progress")
+		c.Assert(e.Timestamp.Year(), Equals, 2018)
+		return nil
+	})
+
+	h.OnAny(func(e *WebhookEvent) error {
+		any++
+		return nil
+	})
+
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	resp, err := http.Post(srv.URL, "application/json", strings.NewReader(`{
+		"timestamp": 1525698237764,
+		"webhookEvent": "jira:issue_updated",
+		"user": {"name": "john"},
+		"issue": {"key": "ABC-1", "fields": {"summary": "Test"}},
+		"changelog": {"id": "10113", "items": [{"field": "status", "fromString": "Open", "toString": "In Progress"}]}
+	}`))
+
+	c.Assert(err, IsNil)
+	c.Assert(resp.StatusCode, Equals, 200)
+
+	resp, err = http.Post(srv.URL, "application/json", strings.NewReader(`{
+		"timestamp": 1525698237764,
+		"webhookEvent": "sprint_started",
+		"sprint": {"id": 1, "state": "active", "startDate": "2018-05-07T12:00:00.000+03:00"}
+	}`))
+
+	c.Assert(err, IsNil)
+	c.Assert(resp.StatusCode, Equals, 200)
+
+	resp, err = http.Post(srv.URL, "application/json", strings.NewReader(`{}`))
+	c.Assert(err, IsNil)
+	c.Assert(resp.StatusCode, Equals, 400)
+
+	resp, err = http.Get(srv.URL)
+	c.Assert(err, IsNil)
+	c.Assert(resp.StatusCode, Equals, 405)
+
+	c.Assert(updated, Equals, 1)
+	c.Assert(any, Equals, 2)
+
+	ts := &Timestamp{}
+	c.Assert(ts.UnmarshalJSON([]byte(`null`)), IsNil)
+	c.Assert(ts.IsZero(), Equals, true)
+	c.Assert(ts.UnmarshalJSON([]byte(`"1525698237764"`)), IsNil)
+	c.Assert(ts.Year(), Equals, 2018)
+	c.Assert(ts.UnmarshalJSON([]byte(`"abc"`)), NotNil)
+}
+
+func (s *JiraSuite) TestResponseDecoding(c *C) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "POST":
+			w.WriteHeader(201)
+			w.Write([]byte(`{"name":"CI"}`))
+		default:
+			w.WriteHeader(404)
+			w.Write([]byte(`{"errorMessages":["Webhook not found"],"errors":{}}`))
+		}
+	}))
+
+	defer srv.Close()
+
+	api, _ := NewAPI(srv.URL, AuthBasic{"john", "Test1234!"})
+	result := &Webhook{}
+
+	statusCode, err := api.doRequest("POST", "/rest/webhooks/1.0/webhook", EmptyParameters{}, result, nil, true)
+
+	c.Assert(err, IsNil)
+	c.Assert(statusCode, Equals, 201)
+	c.Assert(result.Name, Equals, "CI")
+
+	statusCode, err = api.doRequest("GET", "/rest/webhooks/1.0/webhook/13", EmptyParameters{}, result, nil, true)
+
+	c.Assert(err, ErrorMatches, "Webhook not found")
+	c.Assert(statusCode, Equals, 404)
+}
+
+func (s *JiraSuite) TestEnsureWebhook(c *C) {
+	var created, updated int
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			w.Write([]byte(`[{"name":"CI","url":"https://ci.domain.com/hook","events":["jira:issue_created"],"filters":{"issue-related-events-section":"project = ABC"},"self":"` + "http://" + r.Host + `/rest/webhooks/1.0/webhook/12","enabled":true}]`))
+		case "PUT":
+			c.Assert(r.URL.Path, Equals, "/rest/webhooks/1.0/webhook/12")
+			updated++
+			w.Write([]byte(`{"self":"http://` + r.Host + `/rest/webhooks/1.0/webhook/12"}`))
+		case "POST":
+			created++
+			w.WriteHeader(201)
+			w.Write([]byte(`{"self":"http://` + r.Host + `/rest/webhooks/1.0/webhook/13"}`))
+		}
+	}))
+
+	defer srv.Close()
+
+	api, _ := NewAPI(srv.URL, AuthBasic{"john", "Test1234!"})
+
+	webhook := &Webhook{
+		Name:      "CI",
+		URL:       "https://ci.domain.com/hook",
+		Events:    []string{EVENT_ISSUE_CREATED},
+		Filters:   &WebhookFilters{JQL: "project = ABC"},
+		IsEnabled: true,
+	}
+
+	result, err := api.EnsureWebhook(webhook)
+	c.Assert(err, IsNil)
+	c.Assert(result.ID, Equals, "12")
+	c.Assert(created+updated, Equals, 0)
+
+	webhook.Events = append(webhook.Events, EVENT_ISSUE_UPDATED)
+
+	_, err = api.EnsureWebhook(webhook)
+	c.Assert(err, IsNil)
+	c.Assert(updated, Equals, 1)
+
+	webhook.URL = "https://ci.domain.com/hook2"
+
+	result, err = api.EnsureWebhook(webhook)
+	c.Assert(err, IsNil)
+	c.Assert(result.ID, Equals, "13")
+	c.Assert(created, Equals, 1)
+}
+
+func (s *JiraSuite) TestWebhookVerification(c *C) {
+	body := []byte(`{"timestamp":` + strconv.FormatInt(time.Now().UnixMilli(), 10) + `,"webhookEvent":"jira:issue_created"}`)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(body)
+
+	h := NewWebhookHandler()
+	h.Verifier = HMACVerifier{"secret"}
+	h.ReplayWindow = time.Minute
+
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	post := func(signature string) int {
+		req, _ := http.NewRequest("POST", srv.URL, bytes.NewReader(body))
+		req.Header.Set("X-Hub-Signature", signature)
+		resp, err := http.DefaultClient.Do(req)
+		c.Assert(err, IsNil)
+		return resp.StatusCode
+	}
+
+	c.Assert(post("sha256=0000"), Equals, 401)
+	c.Assert(post("sha256="+hex.EncodeToString(mac.Sum(nil))), Equals, 200)
+	c.Assert(post("sha256="+hex.EncodeToString(mac.Sum(nil))), Equals, 401)
+
+	encode := func(v string) string { return base64.RawURLEncoding.EncodeToString([]byte(v)) }
+	exp := strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10)
+	req := httptest.NewRequest("POST", "/webhook/issue?b=2&a=1", nil)
+	payload := encode(`{"alg":"HS256","typ":"JWT"}`) + "." +
+		encode(`{"iss":"jira:1234","exp":`+exp+`,"iat":1,"qsh":"`+getQueryStringHash(req)+`"}`)
+	mac = hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(payload))
+	req.Header.Set("Authorization", "JWT "+payload+"."+base64.RawURLEncoding.EncodeToString(mac.Sum(nil)))
+
+	c.Assert(JWTVerifier{Secret: "secret", Issuer: "jira:1234"}.Verify(req, nil), IsNil)
+	c.Assert(JWTVerifier{Secret: "secret", Issuer: "jira:5678"}.Verify(req, nil), Equals, ErrWebhookInvalidIssuer)
+	c.Assert(JWTVerifier{Secret: "secret1"}.Verify(req, nil), Equals, ErrWebhookInvalidSignature)
+
+	req.URL.RawQuery = "a=1"
+	c.Assert(JWTVerifier{Secret: "secret"}.Verify(req, nil), Equals, ErrWebhookInvalidQSH)
+
+	signJWT := func(claims string) string {
+		payload := encode(`{"alg":"HS256","typ":"JWT"}`) + "." + encode(claims)
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write([]byte(payload))
+		return "JWT " + payload + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+	}
+
+	req.Header.Set("Authorization", signJWT(`{"exp":`+exp+`,"iat":1,"qsh":"context-qsh"}`))
+	c.Assert(JWTVerifier{Secret: "secret"}.Verify(req, nil), Equals, ErrWebhookInvalidQSH)
+	c.Assert(JWTVerifier{Secret: "secret", AllowContextQSH: true}.Verify(req, nil), IsNil)
+
+	h = NewWebhookHandler()
+	h.Verifier = JWTVerifier{Secret: "secret", SkipQSH: true}
+	h.ReplayWindow = time.Minute
+
+	iat := strconv.FormatInt(time.Now().Unix(), 10)
+	token := signJWT(`{"exp":` + exp + `,"iat":` + iat + `}`)
+
+	postJWT := func(token, body string) int {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/", strings.NewReader(body))
+		req.Header.Set("Authorization", token)
+		h.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// Body isn't signed, so replayed token with modified body must be rejected
+	c.Assert(postJWT(token, `{"timestamp":1,"webhookEvent":"jira:issue_created"}`), Equals, 200)
+	c.Assert(postJWT(token, `{"timestamp":2,"webhookEvent":"jira:issue_created"}`), Equals, 401)
+
+	oldToken := signJWT(`{"exp":` + exp + `,"iat":` + strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10) + `}`)
+	c.Assert(postJWT(oldToken, `{"webhookEvent":"jira:issue_created"}`), Equals, 401)
+
+	c.Assert(postJWT(signJWT(`{"jti":"1","exp":`+exp+`,"iat":`+iat+`}`), `{"webhookEvent":"jira:issue_created"}`), Equals, 200)
+	c.Assert(postJWT(signJWT(`{"jti":"1","exp":`+exp+`,"iat":`+iat+`,"iss":"jira"}`), `{"webhookEvent":"jira:issue_created"}`), Equals, 401)
+}
+
+func (s *JiraSuite) TestWebhookReplay(c *C) {
+	var calls int32
+
+	body := []byte(`{"timestamp":` + strconv.FormatInt(time.Now().UnixMilli(), 10) + `,"webhookEvent":"jira:issue_created"}`)
+
+	h := NewWebhookHandler()
+	h.ReplayWindow = time.Minute
+	h.OnAny(func(e *WebhookEvent) error {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			return errors.New("Temporary error")
+		}
+
+		time.Sleep(20 * time.Millisecond)
+
+		return nil
+	})
+
+	post := func() int {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("POST", "/", bytes.NewReader(body)))
+		return w.Code
+	}
+
+	c.Assert(post(), Equals, 500)
+
+	var wg sync.WaitGroup
+	var accepted int32
+
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if post() == 200 {
+				atomic.AddInt32(&accepted, 1)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	c.Assert(atomic.LoadInt32(&accepted), Equals, int32(1))
+	c.Assert(atomic.LoadInt32(&calls), Equals, int32(2))
+}
+
+func (s *JiraSuite) TestWatcher(c *C) {
+	summary, updated := "Test", time.Now().Add(time.Second)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Assert(r.URL.Query().Get("jql"), Matches, `\(project = ABC\) AND updated >= ".*" ORDER BY updated ASC`)
+		w.Write([]byte(`{"total":1,"issues":[{"key":"ABC-1","fields":{"summary":"` + summary + `",` +
+			`"created":"` + updated.Format("2006-01-02T15:04:05.000-0700") + `",` +
+			`"updated":"` + updated.Format("2006-01-02T15:04:05.000-0700") + `"}}]}`))
+	}))
+
+	defer srv.Close()
+
+	api, _ := NewAPI(srv.URL, AuthBasic{"john", "Test1234!"})
+	store := &MemoryCheckpointStore{}
+
+	var events []*WebhookEvent
+
+	h := NewWebhookHandler()
+	h.OnAny(func(e *WebhookEvent) error {
+		events = append(events, e)
+		return nil
+	})
+
+	w := &Watcher{API: api, Handler: h, Store: store, JQL: "project = ABC"}
+
+	c.Assert(w.Poll(), IsNil)
+	c.Assert(w.Poll(), IsNil)
+	c.Assert(events, HasLen, 1)
+	c.Assert(events[0].Type, Equals, EVENT_ISSUE_CREATED)
+
+	summary, updated = "Test 2", updated.Add(time.Second)
+
+	c.Assert(w.Poll(), IsNil)
+	c.Assert(events, HasLen, 2)
+	c.Assert(events[1].Type, Equals, EVENT_ISSUE_UPDATED)
+	c.Assert(events[1].Changelog.Items, HasLen, 1)
+	c.Assert(events[1].Changelog.Items[0].ToString, Equals, "Test 2")
+
+	checkpoint, err := store.Load()
+	c.Assert(err, IsNil)
+	c.Assert(checkpoint.Issues["ABC-1"].Equal(updated.Truncate(time.Millisecond)), Equals, true)
+}
+
+func (s *JiraSuite) TestWatcherSnapshots(c *C) {
+	since := time.Now().Truncate(time.Minute).Add(-10 * time.Minute)
+	format := func(t time.Time) string { return `"` + t.Format("2006-01-02T15:04:05.000-0700") + `"` }
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// ABC-2 is created after poll start, but before ABC-1 update which is
+		// processed first
+		w.Write([]byte(`{"total":2,"issues":[` +
+			`{"key":"ABC-1","fields":{"created":` + format(since.Add(-time.Hour)) + `,"updated":` + format(since.Add(3*time.Minute)) + `}},` +
+			`{"key":"ABC-2","fields":{"created":` + format(since.Add(time.Minute)) + `,"updated":` + format(since.Add(5*time.Minute)) + `}}]}`))
+	}))
+
+	defer srv.Close()
+
+	api, _ := NewAPI(srv.URL, AuthBasic{"john", "Test1234!"})
+
+	var events []*WebhookEvent
+
+	h := NewWebhookHandler()
+	h.OnAny(func(e *WebhookEvent) error {
+		events = append(events, e)
+		return nil
+	})
+
+	w := &Watcher{API: api, Handler: h, Since: since, MaxSnapshots: 1}
+
+	c.Assert(w.Poll(), IsNil)
+	c.Assert(events, HasLen, 2)
+	c.Assert(events[0].Type, Equals, EVENT_ISSUE_UPDATED)
+	c.Assert(events[1].Type, Equals, EVENT_ISSUE_CREATED)
+	c.Assert(w.snapshots, HasLen, 1)
+	c.Assert(w.snapshots["ABC-2"], NotNil)
+}
+
+func (s *JiraSuite) TestWatcherCloud(c *C) {
+	assignee, updated := "1", time.Now().Add(time.Second)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Assert(r.URL.Path, Equals, "/rest/api/3/search/jql")
+
+		fields := `"created":"` + updated.Format("2006-01-02T15:04:05.000-0700") + `",` +
+			`"updated":"` + updated.Format("2006-01-02T15:04:05.000-0700") + `"`
+
+		switch r.URL.Query().Get("nextPageToken") {
+		case "":
+			w.Write([]byte(`{"nextPageToken":"abcd","issues":[{"key":"ABC-1","fields":{` + fields +
+				`,"assignee":{"accountId":"` + assignee + `"}}}]}`))
+		default:
+			w.Write([]byte(`{"isLast":true,"issues":[{"key":"ABC-2","fields":{` + fields + `}}]}`))
+		}
+	}))
+
+	defer srv.Close()
+
+	api, _ := NewCloudAPI(srv.URL, AuthCloudToken{"john@domain.com", "ATATT3xFfGF0"})
+
+	var events []*WebhookEvent
+
+	h := NewWebhookHandler()
+	h.OnAny(func(e *WebhookEvent) error {
+		events = append(events, e)
+		return nil
+	})
+
+	w := &Watcher{API: api, Handler: h}
+
+	c.Assert(w.Poll(), IsNil)
+	c.Assert(events, HasLen, 2)
+
+	assignee, updated = "2", updated.Add(time.Second)
+
+	c.Assert(w.Poll(), IsNil)
+	c.Assert(events, HasLen, 4)
+	c.Assert(events[2].Type, Equals, EVENT_ISSUE_UPDATED)
+	c.Assert(events[2].Changelog.Items, HasLen, 1)
+	c.Assert(events[2].Changelog.Items[0].Field, Equals, "assignee")
+	c.Assert(events[2].Changelog.Items[0].FromString, Equals, "1")
+	c.Assert(events[2].Changelog.Items[0].ToString, Equals, "2")
+}
+
+func (s *JiraSuite) TestCache(c *C) {
+	var hits int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		time.Sleep(20 * time.Millisecond)
+		w.Write([]byte(`[{"id":"1","name":"Blocker"}]`))
+	}))
+
+	defer srv.Close()
+
+	api, _ := NewAPI(srv.URL, AuthBasic{"john", "Test1234!"})
+
+	c.Assert(api.EnableCache(nil, nil), Equals, ErrNilCache)
+	c.Assert(api.EnableCache(NewMemoryCache(), CacheTTL{CACHE_PRIORITIES: time.Minute}), IsNil)
+
+	var wg sync.WaitGroup
+
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			priorities, err := api.GetPriorities()
+			c.Check(err, IsNil)
+			c.Check(priorities, HasLen, 1)
+		}()
+	}
+
+	wg.Wait()
+
+	c.Assert(atomic.LoadInt32(&hits), Equals, int32(1))
+
+	api.GetPriorities()
+	c.Assert(atomic.LoadInt32(&hits), Equals, int32(1))
+
+	api.InvalidateCache(CACHE_PRIORITIES)
+	api.GetPriorities()
+	c.Assert(atomic.LoadInt32(&hits), Equals, int32(2))
+
+	api.GetFields()
+	api.GetFields()
+	c.Assert(atomic.LoadInt32(&hits), Equals, int32(4))
+
+	cache := NewMemoryCache()
+	cache.Set("a", []byte("A"), -time.Second)
+	cache.Set("b", []byte("B"), time.Minute)
+
+	_, ok := cache.Get("a")
+	c.Assert(ok, Equals, false)
+	c.Assert(cache.items, HasLen, 2)
+
+	cache.lastSweep = time.Time{}
+	cache.Set("c", []byte("C"), time.Minute)
+	c.Assert(cache.items, HasLen, 2)
+}
+
+func (s *JiraSuite) TestGetIssues(c *C) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/2/search":
+			c.Assert(r.URL.Query().Get("jql"), Equals, `key in ("ABC-1","ABC-2","ABC-3","ABC-4")`)
+			w.Write([]byte(`{"total":2,"issues":[{"key":"ABC-2"},{"key":"ABC-1"}]}`))
+		case "/rest/api/2/issue/ABC-3":
+			w.Write([]byte(`{"key":"XYZ-3"}`))
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+
+	defer srv.Close()
+
+	api, _ := NewAPI(srv.URL, AuthBasic{"john", "Test1234!"})
+
+	result := api.GetIssues([]string{"ABC-1", "ABC-2", "ABC-3", "ABC-1", "ABC-4"}, IssueParams{}, 4)
+
+	c.Assert(result, HasLen, 5)
+	c.Assert(result[0].Issue.Key, Equals, "ABC-1")
+	c.Assert(result[1].Issue.Key, Equals, "ABC-2")
+	c.Assert(result[2].Issue.Key, Equals, "XYZ-3")
+	c.Assert(result[3].Issue.Key, Equals, "ABC-1")
+	c.Assert(result[4].Key, Equals, "ABC-4")
+	c.Assert(result[4].Error, Equals, ErrNoContent)
+	c.Assert(result[0] != result[3], Equals, true)
+
+	var singleHits int32
+
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/rest/api/2/search" && strings.Contains(r.URL.Query().Get("jql"), "XYZ-1"):
+			w.WriteHeader(400)
+			w.Write([]byte(`{"errorMessages":["An issue with key 'XYZ-9' does not exist for field 'key'."],"errors":{}}`))
+		case r.URL.Path == "/rest/api/2/search":
+			w.WriteHeader(503)
+		case r.URL.Path == "/rest/api/2/issue/XYZ-9":
+			atomic.AddInt32(&singleHits, 1)
+			w.WriteHeader(404)
+		default:
+			atomic.AddInt32(&singleHits, 1)
+			w.Write([]byte(`{"key":"XYZ-1"}`))
+		}
+	}))
+
+	defer srv.Close()
+
+	api, _ = NewAPI(srv.URL, AuthBasic{"john", "Test1234!"})
+
+	result = api.GetIssues([]string{"ABC-1", "ABC-2"}, IssueParams{}, 2)
+
+	c.Assert(result[0].Error, ErrorMatches, ".*status code 503.*")
+	c.Assert(result[1].Error, ErrorMatches, ".*status code 503.*")
+	c.Assert(atomic.LoadInt32(&singleHits), Equals, int32(0))
+
+	result = api.GetIssues([]string{"XYZ-1", "XYZ-9"}, IssueParams{}, 2)
+
+	c.Assert(result[0].Error, IsNil)
+	c.Assert(result[0].Issue.Key, Equals, "XYZ-1")
+	c.Assert(result[1].Error, Equals, ErrNoContent)
+	c.Assert(atomic.LoadInt32(&singleHits), Equals, int32(2))
+}
+
+func (s *JiraSuite) TestRateLimit(c *C) {
+	var hits int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&hits, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(429)
+			return
+		}
+
+		w.Write([]byte(`{"name":"john"}`))
+	}))
+
+	defer srv.Close()
+
+	api, _ := NewAPI(srv.URL, AuthBasic{"john", "Test1234!"})
+
+	c.Assert(api.SetRateLimit(RateLimit{}), Equals, ErrInvalidRateLimit)
+	c.Assert(api.SetRateLimit(RateLimit{ReadRate: 50}), IsNil)
+
+	for range 5 {
+		_, err := api.GetMyself()
+		c.Assert(err, IsNil)
+	}
+
+	c.Assert(atomic.LoadInt32(&hits), Equals, int32(6))
+
+	now := time.Now()
+	bucket := newTokenBucket(10, 2)
+	bucket.last = now
+
+	c.Assert(bucket.take(now), Equals, time.Duration(0))
+	c.Assert(bucket.take(now), Equals, time.Duration(0))
+	c.Assert(bucket.take(now), Equals, 100*time.Millisecond)
+	c.Assert(bucket.take(now.Add(50*time.Millisecond)), Equals, 50*time.Millisecond)
+	c.Assert(bucket.take(now.Add(100*time.Millisecond)), Equals, time.Duration(0))
+
+	bucket.pause(time.Hour)
+	c.Assert(bucket.take(now.Add(time.Second)) > 50*time.Minute, Equals, true)
+
+	c.Assert(parseRetryAfter("120", 0), Equals, 2*time.Minute)
+	c.Assert(parseRetryAfter("", 2), Equals, 4*time.Second)
+}
+
+func (s *JiraSuite) TestMiddleware(c *C) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Assert(r.Header.Get("X-Request-ID"), Equals, "ABCD")
+		c.Assert(r.URL.Query().Get("username"), Equals, "john")
+		w.Write([]byte(`{"name":"john"}`))
+	}))
+
+	defer srv.Close()
+
+	api, _ := NewAPI(srv.URL, AuthBasic{"john", "Test1234!"})
+
+	var calls []string
+
+	api.Use(
+		func(next Handler) Handler {
+			return func(req *Request) (*Response, error) {
+				calls = append(calls, "first")
+				req.HTTP.Header.Set("X-Request-ID", "ABCD")
+				return next(req)
+			}
+		},
+		func(next Handler) Handler {
+			return func(req *Request) (*Response, error) {
+				c.Assert(req.Method, Equals, "GET")
+				c.Assert(req.URI, Equals, "/rest/api/2/user")
+				c.Assert(req.Attempt, Equals, 0)
+
+				resp, err := next(req)
+
+				c.Assert(err, IsNil)
+				c.Assert(resp.StatusCode, Equals, 200)
+				c.Assert(resp.Duration > 0, Equals, true)
+
+				calls = append(calls, "second")
+
+				return resp, err
+			}
+		},
+	)
+
+	user, err := api.GetUser(UserParams{Username: "john"})
+
+	c.Assert(err, IsNil)
+	c.Assert(user.Name, Equals, "john")
+	c.Assert(calls, DeepEquals, []string{"first", "second"})
+}
+
+func (s *JiraSuite) TestLogging(c *C) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" {
+			w.WriteHeader(400)
+			return
+		}
+
+		w.Write([]byte(`{"name":"john"}`))
+	}))
+
+	defer srv.Close()
+
+	api, _ := NewAPI(srv.URL, AuthBasic{"john", "Test1234!"})
+	buf := &bytes.Buffer{}
+
+	c.Assert(api.EnableLogging(LogConfig{}), Equals, ErrNilLogger)
+	c.Assert(api.EnableLogging(LogConfig{
+		Logger:          slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})),
+		SensitiveFields: []string{"username"},
+		LogHeaders:      true,
+		LogBody:         true,
+	}), IsNil)
+
+	_, err := api.GetUser(UserParams{Username: "john"})
+	c.Assert(err, IsNil)
+
+	_, err = api.doRequest("PUT", "/rest/api/2/test", EmptyParameters{}, nil, map[string]any{
+		"name": "test", "secret": "abcd", "items": []any{map[string]string{"password": "1234"}},
+	}, false)
+	c.Assert(err, IsNil)
+
+	out := buf.String()
+
+	c.Assert(strings.Contains(out, "level=DEBUG"), Equals, true)
+	c.Assert(strings.Contains(out, "level=WARN"), Equals, true)
+	c.Assert(strings.Contains(out, "path=/rest/api/2/user"), Equals, true)
+	c.Assert(strings.Contains(out, "status=400"), Equals, true)
+	c.Assert(strings.Contains(out, "retry=0"), Equals, true)
+	c.Assert(strings.Contains(out, "Authorization:[REDACTED]"), Equals, true)
+	c.Assert(strings.Contains(out, "john"), Equals, false)
+	c.Assert(strings.Contains(out, "Test1234"), Equals, false)
+	c.Assert(strings.Contains(out, "abcd"), Equals, false)
+	c.Assert(strings.Contains(out, "1234"), Equals, false)
+	c.Assert(strings.Contains(out, "test"), Equals, true)
+}
+
+func (s *JiraSuite) TestInstrumentation(c *C) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"key":"ABC-1"}`))
+	}))
+
+	defer srv.Close()
+
+	api, _ := NewAPI(srv.URL, AuthBasic{"john", "Test1234!"})
+	inst := &testInstrumentation{}
+
+	api.SetInstrumentation(inst)
+
+	_, err := api.GetIssue("ABC-1", IssueParams{})
+	c.Assert(err, IsNil)
+
+	c.Assert(inst.spans, DeepEquals, []string{"GET /rest/api/2/issue/{key}"})
+	c.Assert(inst.calls, HasLen, 2)
+	c.Assert(inst.calls[0].StatusCode, Equals, 200)
+	c.Assert(inst.finished, Equals, 1)
+
+	c.Assert(getEndpointTemplate("/rest/api/2/issue/createmeta"), Equals, "/rest/api/2/issue/createmeta")
+	c.Assert(getEndpointTemplate("/rest/api/2/issue/ABC-1/comment/1000"), Equals, "/rest/api/2/issue/{key}/comment/{id}")
+	c.Assert(getEndpointTemplate("/rest/api/2/user/avatars?username=john"), Equals, "/rest/api/2/user/avatars")
+	c.Assert(getEndpointTemplate("/rest/api/2/unknown/100"), Equals, "/rest/api/2/unknown/{id}")
+}
+
+func (s *JiraSuite) TestCloud(c *C) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Assert(r.URL.Path, Equals, "/rest/api/3/user")
+		c.Assert(r.URL.Query().Get("accountId"), Equals, "5b10a2844c20165700ede21g")
+		w.Write([]byte(`{"accountId":"5b10a2844c20165700ede21g","accountType":"atlassian","displayName":"John Doe"}`))
+	}))
+
+	defer srv.Close()
+
+	_, err := NewCloudAPI(srv.URL, &AuthSession{User: "john", Password: "Test1234!"})
+	c.Assert(err, Equals, ErrUnsupported)
+
+	api, err := NewCloudAPI(srv.URL, AuthCloudToken{"john@domain.com", "ATATT3xFfGF0"})
+
+	c.Assert(err, IsNil)
+	c.Assert(api.IsCloud(), Equals, true)
+
+	_, err = api.GetUser(UserParams{Username: "john"})
+	c.Assert(err, Equals, ErrEmptyAccountID)
+
+	user, err := api.GetUser(UserParams{AccountID: "5b10a2844c20165700ede21g"})
+
+	c.Assert(err, IsNil)
+	c.Assert(user.AccountID, Equals, "5b10a2844c20165700ede21g")
+	c.Assert(user.DisplayName, Equals, "John Doe")
+
+	_, err = api.GetUserAvatars("5b10a2844c20165700ede21g")
+	c.Assert(err, Equals, ErrUnsupported)
+	_, err = api.SearchUsers(UserSearchParams{Username: "john"})
+	c.Assert(err, Equals, ErrUnsupported)
+	_, err = api.GetUsersByPermissions(UserPermissionParams{Username: "john", Permissions: []string{"BROWSE"}})
+	c.Assert(err, Equals, ErrUnsupported)
+	_, err = api.GetGroup(GroupParams{Name: "jira-users", Expand: []string{"users"}})
+	c.Assert(err, Equals, ErrUnsupported)
+}
+
+func (s *JiraSuite) TestADF(c *C) {
+	fields := &IssueFields{}
+	err := json.Unmarshal([]byte(`{
+		"description": {"type":"doc","version":1,"content":[
+			{"type":"paragraph","content":[{"type":"text","text":"Hello "},{"type":"text","text":"world","marks":[{"type":"strong"}]}]}
+		]},
+		"environment": "Linux"
+	}`), fields)
+
+	c.Assert(err, IsNil)
+	c.Assert(fields.Description, Equals, "Hello world")
+	c.Assert(fields.DescriptionADF, NotNil)
+	c.Assert(fields.Environment, Equals, "Linux")
+	c.Assert(fields.EnvironmentADF, IsNil)
+
+	comment := &Comment{}
+	err = json.Unmarshal([]byte(`{"id":"1","body":{"type":"doc","version":1,"content":[{"type":"rule"}]}}`), comment)
+
+	c.Assert(err, IsNil)
+	c.Assert(comment.ID, Equals, "1")
+	c.Assert(comment.BodyADF.Content[0].Type, Equals, ADF_RULE)
+
+	worklog := &Worklog{}
+	err = json.Unmarshal([]byte(`{"id":"2","comment":"Done","timeSpentSeconds":60}`), worklog)
+
+	c.Assert(err, IsNil)
+	c.Assert(worklog.Comment, Equals, "Done")
+	c.Assert(worklog.TimeSpentSeconds, Equals, 60)
+
+	doc := NewADF(
+		ADFHeading(2, ADFText("Title")),
+		ADFParagraph(ADFText("Go"), ADFText(" to "), ADFText("site").Link("https://domain.com").Bold()),
+		ADFBulletList(
+			ADFListItem(ADFParagraph(ADFText("one")), ADFOrderedList(ADFListItem(ADFParagraph(ADFText("two").Code())))),
+		),
+		ADFCodeBlock("go", "fmt.Println(1 < 2)"),
+		ADFTable(
+			ADFTableRow(ADFTableHeader(ADFParagraph(ADFText("A"))), ADFTableHeader(ADFParagraph(ADFText("B")))),
+			ADFTableRow(ADFTableCell(ADFParagraph(ADFText("1"))), ADFTableCell(ADFParagraph(ADFText("2")))),
+		),
+	)
+
+	c.Assert(doc.PlainText(), Equals, "Title\n\nGo to site\n\none\ntwo\n\nfmt.Println(1 < 2)\n\nA\tB\n1\t2")
+	c.Assert(doc.Markdown(), Equals, "## Title\n\nGo to [**site**](https://domain.com)\n\n- one\n  1. `two`\n\n```go\nfmt.Println(1 < 2)\n```\n\n| A | B |\n| --- | --- |\n| 1 | 2 |")
+	c.Assert(doc.HTML(), Equals, `<h2>Title</h2><p>Go to <a href="https://domain.com"><strong>site</strong></a></p><ul><li><p>one</p><ol><li><p><code>two</code></p></li></ol></li></ul><pre><code class="language-go">fmt.Println(1 &lt; 2)</code></pre><table><tr><th><p>A</p></th><th><p>B</p></th></tr><tr><td><p>1</p></td><td><p>2</p></td></tr></table>`)
+
+	data, _ := json.Marshal(NewADF(ADFParagraph(ADFText("A"))))
+	c.Assert(string(data), Equals, `{"type":"doc","content":[{"type":"paragraph","content":[{"type":"text","text":"A"}]}],"version":1}`)
+}
+
+func (s *JiraSuite) TestWiki(c *C) {
+	src := "h2. Title\n\nHello *bold* and _well-known_ {{code}} [~john] [Site|https://domain.com] !image.png|thumbnail!\nNext\n\n" +
+		"* one\n*# two\n\n||A||B||\n|[a|https://a.com]|2|\n\n{code:go}\nfmt.Println(1 < 2)\n{code}\n{quote}\nquoted -text-\n{quote}\n----"
+
+	c.Assert(WikiToMarkdown(src), Equals, "## Title\n\n"+
+		"Hello **bold** and *well-known* `code` @john [Site](https://domain.com) ![](image.png)  \nNext\n\n"+
+		"- one\n  1. two\n\n| A | B |\n| --- | --- |\n| [a](https://a.com) | 2 |\n\n"+
+		"```go\nfmt.Println(1 < 2)\n```\n\n> quoted ~~text~~\n\n---")
+
+	c.Assert(WikiToHTML("h1. A & B\n\n* *x*\n{noformat}<b>{noformat}"), Equals,
+		"<h1>A &amp; B</h1><ul><li><p><strong>x</strong></p></li></ul><pre><code>&lt;b&gt;</code></pre>")
+
+	c.Assert(WikiToHTML("[click|javascript:alert(1)] [x| javascript:alert(1)] [y|JaVaScRiPt:alert(1)]"), Equals,
+		"<p>click x y</p>")
+	c.Assert(WikiToHTML("!javascript:alert(1)! [a|https://a.com] [m|mailto:a@b.c] [r|/browse/ABC-1]"), Equals,
+		`<p>javascript:alert(1) <a href="https://a.com">a</a> <a href="mailto:a@b.c">m</a> <a href="/browse/ABC-1">r</a></p>`)
+	c.Assert(NewADF(ADFParagraph(&ADFNode{Type: ADF_INLINE_CARD, Attrs: map[string]any{"url": "data:text/html,1"}})).HTML(),
+		Equals, "<p>data:text/html,1</p>")
+
+	noHref := NewADF(ADFParagraph(ADFText("a").addMark(&ADFMark{Type: ADF_MARK_LINK, Attrs: map[string]any{"href": nil}})))
+	c.Assert(noHref.Markdown(), Equals, "a")
+	c.Assert(noHref.HTML(), Equals, "<p>a</p>")
+
+	c.Assert(WikiToPlainText("*Hello* [~john]\n----\n|1|2|"), Equals, "Hello @john\n\n1\t2")
+
+	c.Assert(MarkdownToWiki("# Title\n\n**bold** *it* ~~s~~ `a*b` [l](https://a.com) ![i](i.png)\n\n- a\n  1. b\n\n> q\n\n```go\nx := 1\n```\n\n| A | B |\n|---|---|\n| 1 | 2 |"),
+		Equals, "h1. Title\n\n*bold* _it_ -s- {{a*b}} [l|https://a.com] !i.png!\n\n* a\n*# b\n\n{quote}\nq\n{quote}\n\n{code:go}\nx := 1\n{code}\n\n||A||B||\n|1|2|")
+}
+
+func (s *JiraSuite) TestSearchIter(c *C) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		switch r.URL.Path {
+		case "/rest/api/2/search":
+			switch query.Get("startAt") {
+			case "":
+				if query.Has("maxResults") {
+					c.Assert(query.Get("maxResults"), Equals, "0")
+					w.Write([]byte(`{"startAt":0,"total":5,"issues":[]}`))
+					return
+				}
+
+				w.Write([]byte(`{"startAt":0,"total":3,"issues":[{"key":"ABC-1"},{"key":"ABC-2"}]}`))
+			case "2":
+				w.Write([]byte(`{"startAt":2,"total":3,"issues":[{"key":"ABC-3"}]}`))
+			}
+		case "/rest/api/3/search/jql":
+			c.Assert(query.Get("startAt"), Equals, "")
+			c.Assert(query.Get("fields"), Equals, "*navigable")
+
+			switch query.Get("nextPageToken") {
+			case "":
+				w.Write([]byte(`{"nextPageToken":"abcd","issues":[{"key":"ABC-1"}]}`))
+			case "abcd":
+				w.Write([]byte(`{"isLast":true,"issues":[{"key":"ABC-2"}]}`))
+			}
+		case "/rest/api/3/search/approximate-count":
+			c.Assert(r.Method, Equals, "POST")
+			w.Write([]byte(`{"count":42}`))
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+
+	defer srv.Close()
+
+	var keys []string
+
+	api, _ := NewAPI(srv.URL, AuthBasic{"john", "Test1234!"})
+	it := api.SearchIter(SearchParams{JQL: "project = ABC"})
+
+	for it.Next() {
+		keys = append(keys, it.Issue().Key)
+	}
+
+	c.Assert(it.Err(), IsNil)
+	c.Assert(keys, DeepEquals, []string{"ABC-1", "ABC-2", "ABC-3"})
+
+	count, err := api.CountIssues("project = ABC")
+	c.Assert(err, IsNil)
+	c.Assert(count, Equals, 5)
+
+	keys = nil
+
+	api, _ = NewCloudAPI(srv.URL, AuthCloudToken{"john@domain.com", "ATATT3xFfGF0"})
+	it = api.SearchIter(SearchParams{JQL: "project = ABC"})
+
+	for it.Next() {
+		keys = append(keys, it.Issue().Key)
+	}
+
+	c.Assert(it.Err(), IsNil)
+	c.Assert(keys, DeepEquals, []string{"ABC-1", "ABC-2"})
+
+	count, err = api.CountIssues("project = ABC")
+	c.Assert(err, IsNil)
+	c.Assert(count, Equals, 42)
+
+	_, err = api.Search(SearchParams{JQL: "project = ABC", DisableQueryValidation: true})
+	c.Assert(err, Equals, ErrInvalidInput)
+}
+
+func (s *JiraSuite) TestCapabilities(c *C) {
+	var infoHits int32
+
+	version := `"9.12.2","versionNumbers":[9,12,2]`
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/2/serverInfo":
+			if atomic.AddInt32(&infoHits, 1) == 1 {
+				w.WriteHeader(503)
+				return
+			}
+			w.Write([]byte(`{"version":` + version + `,"deploymentType":"Server"}`))
+		case "/rest/api/2/cluster/nodes":
+			if strings.HasPrefix(version, `"9.12.2"`) {
+				w.WriteHeader(404)
+				return
+			}
+			w.Write([]byte(`[{"nodeId":"node1","state":"ACTIVE"}]`))
+		case "/rest/api/3/serverInfo":
+			w.Write([]byte(`{"version":"1001.0.0-SNAPSHOT","versionNumbers":[1001,0,0],"deploymentType":"Cloud"}`))
+		case "/rest/api/2/issue/createmeta":
+			w.Write([]byte(`{"projects":[{"id":"10000","key":"ABC"}]}`))
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+
+	defer srv.Close()
+
+	api, _ := NewAPI(srv.URL, AuthBasic{"john", "Test1234!"})
+
+	c.Assert(api.Connect(), NotNil)
+	c.Assert(api.Connect(), NotNil)
+	c.Assert(atomic.LoadInt32(&infoHits), Equals, int32(1))
+
+	// Legacy endpoint must be used if version can't be detected
+	_, err := api.GetCreateMeta(CreateMetaParams{ProjectKeys: []string{"ABC"}})
+	c.Assert(err, IsNil)
+
+	api.capabilities.errTime = time.Time{}
+	caps, err := api.Capabilities()
+
+	c.Assert(err, IsNil)
+	c.Assert(caps.Deployment, Equals, DEPLOYMENT_SERVER)
+	c.Assert(caps.MajorVersion, Equals, 9)
+	c.Assert(caps.MinorVersion, Equals, 12)
+	c.Assert(caps.IsVersionAtLeast(9, 12), Equals, true)
+	c.Assert(caps.IsVersionAtLeast(9, 13), Equals, false)
+	c.Assert(caps.Supports(CAP_PAGINATED_CREATE_META), Equals, true)
+	c.Assert(caps.Supports(CAP_LEGACY_CREATE_META), Equals, false)
+	c.Assert(caps.Supports("unknown"), Equals, false)
+
+	_, err = api.Capabilities()
+	c.Assert(err, IsNil)
+	c.Assert(atomic.LoadInt32(&infoHits), Equals, int32(2))
+
+	version = `"8.20.1","versionNumbers":[8,20,1]`
+	api, _ = NewAPI(srv.URL, AuthBasic{"john", "Test1234!"})
+
+	c.Assert(api.Connect(), IsNil)
+
+	caps, _ = api.Capabilities()
+	c.Assert(caps.Deployment, Equals, DEPLOYMENT_DATA_CENTER)
+
+	version = `"10.3.0","versionNumbers":[10,3,0]`
+	api, _ = NewAPI(srv.URL, AuthBasic{"john", "Test1234!"})
+
+	caps, _ = api.Capabilities()
+	c.Assert(caps.Deployment, Equals, DEPLOYMENT_DATA_CENTER)
+
+	version = `"8.3.0","versionNumbers":[8,3,0]`
+	api, _ = NewAPI(srv.URL, AuthBasic{"john", "Test1234!"})
+
+	_, err = api.GetCreateMetaIssueTypes("ABC", CreateMetaPageParams{})
+	c.Assert(err, Equals, ErrUnsupported)
+	_, err = api.GetCreateMetaFields("ABC", "1", CreateMetaPageParams{})
+	c.Assert(err, Equals, ErrUnsupported)
+
+	api, _ = NewCloudAPI(srv.URL, AuthCloudToken{"john@domain.com", "ATATT3xFfGF0"})
+
+	caps, err = api.Capabilities()
+
+	c.Assert(err, IsNil)
+	c.Assert(caps.IsCloud(), Equals, true)
+	c.Assert(caps.Supports(CAP_PAGINATED_CREATE_META), Equals, true)
+}
+
+func (s *JiraSuite) TestPaginatedCreateMeta(c *C) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		startAt := r.URL.Query().Get("startAt")
+
+		switch r.URL.Path {
+		case "/rest/api/2/serverInfo":
+			w.Write([]byte(`{"version":"9.4.0","versionNumbers":[9,4,0]}`))
+		case "/rest/api/2/project/ABC":
+			w.Write([]byte(`{"id":"10000","key":"ABC"}`))
+		case "/rest/api/2/project":
+			w.Write([]byte(`[{"id":"10000","key":"ABC"},{"id":"10001","key":"DEF"},{"id":"10002","key":"GHI"}]`))
+		case "/rest/api/2/issue/createmeta/10001/issuetypes":
+			w.WriteHeader(403)
+		case "/rest/api/2/issue/createmeta/10002/issuetypes":
+			w.Write([]byte(`{"startAt":0,"total":0,"isLast":true,"values":[]}`))
+		case "/rest/api/2/issue/createmeta/10000/issuetypes":
+			if startAt == "" {
+				w.Write([]byte(`{"startAt":0,"total":2,"values":[{"id":"1","name":"Bug"}]}`))
+			} else {
+				w.Write([]byte(`{"startAt":1,"total":2,"values":[{"id":"2","name":"Task"}]}`))
+			}
+		case "/rest/api/2/issue/createmeta/10000/issuetypes/1":
+			w.Write([]byte(`{"startAt":0,"total":1,"isLast":true,"values":[{"fieldId":"summary","name":"Summary","required":true}]}`))
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+
+	defer srv.Close()
+
+	api, _ := NewAPI(srv.URL, AuthBasic{"john", "Test1234!"})
+
+	projects, err := api.GetCreateMeta(CreateMetaParams{
+		ProjectKeys:    []string{"ABC"},
+		IssueTypeNames: []string{"Bug"},
+		Expand:         []string{"projects.issuetypes.fields"},
+	})
+
+	c.Assert(err, IsNil)
+	c.Assert(projects, HasLen, 1)
+	c.Assert(projects[0].Key, Equals, "ABC")
+	c.Assert(projects[0].IssueTypes, HasLen, 1)
+	c.Assert(projects[0].IssueTypes[0].Name, Equals, "Bug")
+	c.Assert(projects[0].IssueTypes[0].Fields["summary"].IsRequired, Equals, true)
+
+	// Projects where user can't create issues must be skipped
+	projects, err = api.GetCreateMeta(CreateMetaParams{})
+
+	c.Assert(err, IsNil)
+	c.Assert(projects, HasLen, 1)
+	c.Assert(projects[0].Key, Equals, "ABC")
+	c.Assert(projects[0].IssueTypes, HasLen, 2)
+
+	issueTypes, err := api.GetCreateMetaIssueTypes("10000", CreateMetaPageParams{})
+
+	c.Assert(err, IsNil)
+	c.Assert(issueTypes.Total, Equals, 2)
+	c.Assert(issueTypes.Data, HasLen, 1)
+
+	collection := &CreateMetaFieldCollection{}
+	err = json.Unmarshal([]byte(`{"total":1,"fields":[{"fieldId":"summary"}]}`), collection)
+
+	c.Assert(err, IsNil)
+	c.Assert(collection.Data[0].FieldID, Equals, "summary")
+}
+
+func (s *JiraSuite) TestIssueLinks(c *C) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		switch r.Method + " " + r.URL.Path {
+		case "POST /rest/api/2/issueLink":
+			c.Assert(string(body), Equals, `{"type":{"name":"Blocks"},"inwardIssue":{"key":"ABC-1"},"outwardIssue":{"key":"ABC-2"},"comment":{"body":"Linked"}}`)
+			w.WriteHeader(201)
+		case "DELETE /rest/api/2/issueLink/10000":
+			w.WriteHeader(204)
+		case "POST /rest/api/2/issueLinkType":
+			c.Assert(string(body), Equals, `{"name":"Clones","inward":"is cloned by","outward":"clones"}`)
+			w.WriteHeader(201)
+			w.Write([]byte(`{"id":"10001","name":"Clones","inward":"is cloned by","outward":"clones"}`))
+		case "PUT /rest/api/2/issueLinkType/10001":
+			w.Write(body)
+		case "DELETE /rest/api/2/issueLinkType/10001":
+			w.WriteHeader(204)
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+
+	defer srv.Close()
+
+	api, _ := NewAPI(srv.URL, AuthBasic{"john", "Test1234!"})
+
+	c.Assert(api.CreateIssueLink("Blocks", "ABC-1", "ABC-2", "Linked"), IsNil)
+	c.Assert(api.DeleteIssueLink("10000"), IsNil)
+	c.Assert(api.DeleteIssueLink("10002"), Equals, ErrNoContent)
+
+	linkType, err := api.CreateIssueLinkType(&LinkType{Name: "Clones", Inward: "is cloned by", Outward: "clones"})
+
+	c.Assert(err, IsNil)
+	c.Assert(linkType.ID, Equals, "10001")
+
+	linkType.Outward = "duplicates"
+	linkType, err = api.UpdateIssueLinkType(linkType)
+
+	c.Assert(err, IsNil)
+	c.Assert(linkType.Outward, Equals, "duplicates")
+
+	_, err = api.UpdateIssueLinkType(&LinkType{Name: "Test"})
+	c.Assert(err, Equals, ErrInvalidInput)
+
+	c.Assert(api.DeleteIssueLinkType("10001"), IsNil)
+}
+
+func (s *JiraSuite) TestRemoteLinks(c *C) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		switch r.Method + " " + r.URL.Path {
+		case "POST /rest/api/2/issue/ABC-1/remotelink":
+			c.Assert(string(body), Equals, `{"object":{"url":"https://ci.domain.com/builds/1","title":"Build #1","summary":"Passed","status":{"resolved":true}},"globalId":"build=1","relationship":"built by"}`)
+			w.WriteHeader(201)
+			w.Write([]byte(`{"id":10000,"self":"https://jira.domain.com/rest/api/2/issue/ABC-1/remotelink/10000"}`))
+		case "PUT /rest/api/2/issue/ABC-1/remotelink/10000":
+			w.WriteHeader(204)
+		case "DELETE /rest/api/2/issue/ABC-1/remotelink/10000":
+			w.WriteHeader(204)
+		case "DELETE /rest/api/2/issue/ABC-1/remotelink":
+			c.Assert(r.URL.Query().Get("globalId"), Equals, "build=1")
+			w.WriteHeader(204)
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+
+	defer srv.Close()
+
+	api, _ := NewAPI(srv.URL, AuthBasic{"john", "Test1234!"})
+
+	link, err := api.CreateIssueRemoteLink("ABC-1", &RemoteLink{
+		GlobalID:     "build=1",
+		Relationship: "built by",
+		Info: &RemoteLinkInfo{
+			URL:     "https://ci.domain.com/builds/1",
+			Title:   "Build #1",
+			Summary: "Passed",
+			Status:  &RemoteLinkStatus{IsResolved: true},
+		},
+	})
+
+	c.Assert(err, IsNil)
+	c.Assert(link.ID, Equals, 10000)
+	c.Assert(link.GlobalID, Equals, "build=1")
+
+	c.Assert(api.UpdateIssueRemoteLink("ABC-1", link), IsNil)
+	c.Assert(api.UpdateIssueRemoteLink("ABC-1", &RemoteLink{}), Equals, ErrWrongLinkID)
+	c.Assert(api.DeleteIssueRemoteLink("ABC-1", "10000"), IsNil)
+	c.Assert(api.DeleteIssueRemoteLinkByGlobalID("ABC-1", "build=1"), IsNil)
+	c.Assert(api.DeleteIssueRemoteLinkByGlobalID("ABC-1", ""), Equals, ErrInvalidInput)
+}
+
+func (s *JiraSuite) TestIssueGraph(c *C) {
+	issues := map[string]string{
+		"ABC-1": `{"key":"ABC-1","fields":{"summary":"Root","timeestimate":3600,"issuelinks":[
+			{"type":{"name":"Blocks","inward":"is blocked by","outward":"blocks"},"outwardIssue":{"key":"ABC-2"}},
+			{"type":{"name":"Relates","inward":"relates to","outward":"relates to"},"outwardIssue":{"key":"ABC-5"}}
+		],"subtasks":[{"key":"ABC-3"}]}}`,
+		"ABC-2": `{"key":"ABC-2","fields":{"summary":"Say \"hi\"","issuelinks":[
+			{"type":{"name":"Blocks","inward":"is blocked by","outward":"blocks"},"inwardIssue":{"key":"ABC-1"}},
+			{"type":{"name":"Blocks","inward":"is blocked by","outward":"blocks"},"outwardIssue":{"key":"ABC-4"}}
+		]}}`,
+		"ABC-3": `{"key":"ABC-3","fields":{"summary":"Sub-task","timeestimate":1800,"parent":{"key":"ABC-1"}}}`,
+		"ABC-4": `{"key":"ABC-4","fields":{"summary":"Last","issuelinks":[
+			{"type":{"name":"Blocks","inward":"is blocked by","outward":"blocks"},"outwardIssue":{"key":"ABC-2"}}
+		]}}`,
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/rest/api/2/search":
+			var found []string
+
+			for key, data := range issues {
+				if strings.Contains(r.URL.Query().Get("jql"), `"`+key+`"`) {
+					found = append(found, data)
+				}
+			}
+
+			w.Write([]byte(`{"total":` + strconv.Itoa(len(found)) + `,"issues":[` + strings.Join(found, ",") + `]}`))
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+
+	defer srv.Close()
+
+	api, _ := NewAPI(srv.URL, AuthBasic{"john", "Test1234!"})
+
+	_, err := api.BuildIssueGraph([]string{"ABC-9"}, GraphParams{})
+	c.Assert(err, NotNil)
+
+	graph, err := api.BuildIssueGraph([]string{"ABC-1"}, GraphParams{
+		LinkTypes: []string{"is blocked by", GRAPH_EDGE_PARENT},
+	})
+
+	c.Assert(err, IsNil)
+	c.Assert(graph.Nodes, HasLen, 4)
+	c.Assert(graph.Edges, HasLen, 4)
+	c.Assert(graph.FindCycles(), DeepEquals, [][]string{{"ABC-2", "ABC-4"}})
+
+	_, err = graph.TopologicalOrder()
+	c.Assert(err, Equals, ErrGraphCycle)
+
+	order, err := graph.TopologicalOrder(GRAPH_EDGE_PARENT)
+	c.Assert(err, IsNil)
+	c.Assert(order, DeepEquals, []string{"ABC-1", "ABC-2", "ABC-4", "ABC-3"})
+
+	graph.Edges = graph.Edges[:3]
+
+	path, err := graph.CriticalPath(nil)
+	c.Assert(err, IsNil)
+	c.Assert(path, DeepEquals, []string{"ABC-1", "ABC-2", "ABC-4"})
+
+	path, err = graph.CriticalPath(func(issue *Issue) int { return issue.Fields.TimeEstimate }, GRAPH_EDGE_PARENT)
+	c.Assert(err, IsNil)
+	c.Assert(path, DeepEquals, []string{"ABC-1", "ABC-3"})
+
+	c.Assert(strings.Contains(graph.DOT(), `"ABC-2" [label="ABC-2\nSay \"hi\""];`), Equals, true)
+	c.Assert(strings.Contains(graph.DOT(), `"ABC-1" -> "ABC-2" [label="blocks"];`), Equals, true)
+	c.Assert(strings.Contains(graph.Mermaid(), `ABC_2["ABC-2: Say #quot;hi#quot;"]`), Equals, true)
+	c.Assert(strings.Contains(graph.Mermaid(), `ABC_1 -->|"blocks"| ABC_2`), Equals, true)
+
+	epicSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch jql := r.URL.Query().Get("jql"); {
+		case strings.HasPrefix(jql, "parent = "), strings.HasPrefix(jql, "cf[10014] = "):
+			w.Write([]byte(`{"isLast":true,"issues":[{"key":"ABC-11","fields":{"summary":"Story"}}]}`))
+		case strings.Contains(jql, `"ABC-10"`):
+			w.Write([]byte(`{"isLast":true,"issues":[{"key":"ABC-10","fields":{"summary":"Épica",` +
+				`"issuetype":{"name":"Épica","hierarchyLevel":1}}}]}`))
+		case strings.Contains(jql, `"ABC-11"`):
+			w.Write([]byte(`{"isLast":true,"issues":[{"key":"ABC-11","fields":{"summary":"Story",` +
+				`"customfield_10014":"ABC-10","parent":{"key":"ABC-10","fields":{"issuetype":{"name":"Épica","hierarchyLevel":1}}}}}]}`))
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+
+	defer epicSrv.Close()
+
+	api, _ = NewCloudAPI(epicSrv.URL, AuthCloudToken{"john@domain.com", "ATATT3xFfGF0"})
+
+	graph, err = api.BuildIssueGraph([]string{"ABC-10"}, GraphParams{Depth: 2})
+
+	c.Assert(err, IsNil)
+	c.Assert(graph.Edges, DeepEquals, []*GraphEdge{{"ABC-10", "ABC-11", GRAPH_EDGE_EPIC, GRAPH_EDGE_EPIC}})
+
+	graph, err = api.BuildIssueGraph([]string{"ABC-10"}, GraphParams{Depth: 1, EpicIssueType: "Epic"})
+
+	c.Assert(err, IsNil)
+	c.Assert(graph.Edges, HasLen, 0)
+
+	api, _ = NewAPI(epicSrv.URL, AuthBasic{"john", "Test1234!"})
+
+	graph, err = api.BuildIssueGraph([]string{"ABC-10"}, GraphParams{
+		Depth: 2, EpicIssueType: "Épica", EpicLinkField: "customfield_10014",
+		LinkTypes: []string{GRAPH_EDGE_EPIC},
+	})
+
+	c.Assert(err, IsNil)
+	c.Assert(graph.Edges, DeepEquals, []*GraphEdge{{"ABC-10", "ABC-11", GRAPH_EDGE_EPIC, GRAPH_EDGE_EPIC}})
+	c.Assert(getJQLFieldName("summary"), Equals, `"summary"`)
+}
+
+func (s *JiraSuite) TestWatchersAndVotes(c *C) {
+	var mu sync.Mutex
+	var calls []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		mu.Lock()
+		calls = append(calls, r.Method+" "+r.URL.Path+" "+r.URL.RawQuery+string(body))
+		mu.Unlock()
+
+		switch {
+		case r.URL.Path == "/rest/api/2/issue/ABC-404/votes",
+			r.URL.Path == "/rest/api/2/issue/ABC-404/watchers":
+			w.WriteHeader(404)
+		case r.Method == "GET" && strings.HasSuffix(r.URL.Path, "/watchers"):
+			w.Write([]byte(`{"watchCount":2,"watchers":[
+				{"name":"john","accountId":"1"},{"name":"bob","accountId":"2"}
+			]}`))
+		default:
+			w.WriteHeader(204)
+		}
+	}))
+
+	defer srv.Close()
+
+	api, _ := NewAPI(srv.URL, AuthBasic{"john", "Test1234!"})
+
+	c.Assert(api.AddVote("ABC-1"), IsNil)
+	c.Assert(api.RemoveVote("ABC-1"), IsNil)
+	c.Assert(api.AddVote("ABC-404"), Equals, ErrNoContent)
+	c.Assert(api.AddWatcher("ABC-1", ""), IsNil)
+	c.Assert(api.AddWatcher("ABC-1", "alice"), IsNil)
+	c.Assert(api.RemoveWatcher("ABC-1", "alice"), IsNil)
+	c.Assert(api.RemoveWatcher("ABC-1", ""), Equals, ErrInvalidInput)
+	c.Assert(api.RemoveWatcher("ABC-404", "alice"), Equals, ErrNoContent)
+	c.Assert(api.EnsureWatchers("ABC-404", nil), Equals, ErrNoContent)
+
+	c.Assert(calls, DeepEquals, []string{
+		"POST /rest/api/2/issue/ABC-1/votes ",
+		"DELETE /rest/api/2/issue/ABC-1/votes ",
+		"POST /rest/api/2/issue/ABC-404/votes ",
+		"POST /rest/api/2/issue/ABC-1/watchers ",
+		`POST /rest/api/2/issue/ABC-1/watchers "alice"`,
+		"DELETE /rest/api/2/issue/ABC-1/watchers username=alice",
+		"DELETE /rest/api/2/issue/ABC-404/watchers username=alice",
+		"GET /rest/api/2/issue/ABC-404/watchers ",
+	})
+
+	calls = nil
+
+	c.Assert(api.EnsureWatchers("ABC-1", []string{"john", "alice", "alice"}), IsNil)
+	c.Assert(calls, DeepEquals, []string{
+		"GET /rest/api/2/issue/ABC-1/watchers ",
+		`POST /rest/api/2/issue/ABC-1/watchers "alice"`,
+		"DELETE /rest/api/2/issue/ABC-1/watchers username=bob",
+	})
+
+	api, _ = NewCloudAPI(srv.URL, AuthBasic{"john", "Test1234!"})
+	calls = nil
+
+	c.Assert(api.EnsureWatchers("ABC-1", []string{"2", "3"}), IsNil)
+	c.Assert(calls, DeepEquals, []string{
+		"GET /rest/api/3/issue/ABC-1/watchers ",
+		`POST /rest/api/3/issue/ABC-1/watchers "3"`,
+		"DELETE /rest/api/3/issue/ABC-1/watchers accountId=1",
+	})
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+type testInstrumentation struct {
+	spans    []string
+	calls    []*CallInfo
+	finished int
+}
+
+func (i *testInstrumentation) StartSpan(method, endpoint string) Span {
+	i.spans = append(i.spans, method+" "+endpoint)
+	return i
+}
+
+func (i *testInstrumentation) IncRequests(info *CallInfo) {
+	i.calls = append(i.calls, info)
+}
+
+func (i *testInstrumentation) ObserveDuration(info *CallInfo) {
+	i.calls = append(i.calls, info)
+}
+
+func (i *testInstrumentation) Finish(info *CallInfo) {
+	i.finished++
 }
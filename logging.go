@@ -0,0 +1,243 @@
+package jira
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/valyala/fasthttp"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// REDACTED is placeholder for redacted values in logs
+const REDACTED = "[REDACTED]"
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// LogConfig contains requests logging configuration
+type LogConfig struct {
+	// Logger is logger for requests
+	Logger *slog.Logger
+
+	// Level is level for successful requests (slog.LevelDebug is used if not set)
+	Level slog.Leveler
+
+	// ErrorLevel is level for failed requests (slog.LevelWarn is used if not set)
+	ErrorLevel slog.Leveler
+
+	// SensitiveFields is list of additional query and body fields which values
+	// must be redacted (DefaultSensitiveFields are always redacted)
+	SensitiveFields []string
+
+	// LogHeaders enables request headers logging
+	LogHeaders bool
+
+	// LogBody enables request body logging
+	LogBody bool
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// requestLogger is requests logger
+type requestLogger struct {
+	logger     *slog.Logger
+	level      slog.Leveler
+	errorLevel slog.Leveler
+	sensitive  map[string]bool
+	logHeaders bool
+	logBody    bool
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// DefaultSensitiveFields is list of query and body fields which values are always
+// redacted in logs
+var DefaultSensitiveFields = []string{
+	"password", "token", "access_token", "refresh_token",
+	"client_secret", "secret", "code",
+}
+
+// ErrNilLogger is returned if logger is nil
+var ErrNilLogger = errors.New("Logger can't be nil")
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// EnableLogging enables logging of all requests sent to Jira. Authorization
+// header and sensitive fields are always redacted.
+func (api *API) EnableLogging(config LogConfig) error {
+	if config.Logger == nil {
+		return ErrNilLogger
+	}
+
+	logger := &requestLogger{
+		logger:     config.Logger,
+		level:      config.Level,
+		errorLevel: config.ErrorLevel,
+		sensitive:  map[string]bool{},
+		logHeaders: config.LogHeaders,
+		logBody:    config.LogBody,
+	}
+
+	if logger.level == nil {
+		logger.level = slog.LevelDebug
+	}
+
+	if logger.errorLevel == nil {
+		logger.errorLevel = slog.LevelWarn
+	}
+
+	for _, field := range DefaultSensitiveFields {
+		logger.sensitive[strings.ToLower(field)] = true
+	}
+
+	for _, field := range config.SensitiveFields {
+		logger.sensitive[strings.ToLower(field)] = true
+	}
+
+	api.logger = logger
+
+	return nil
+}
+
+// DisableLogging disables requests logging
+func (api *API) DisableLogging() {
+	api.logger = nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// wrap wraps handler with logging
+func (l *requestLogger) wrap(next Handler) Handler {
+	return func(req *Request) (*Response, error) {
+		resp, err := next(req)
+		l.log(req, resp, err)
+		return resp, err
+	}
+}
+
+// log writes record about request to log
+func (l *requestLogger) log(req *Request, resp *Response, err error) {
+	level := l.level.Level()
+
+	if err != nil || resp.StatusCode < 200 || resp.StatusCode > 299 {
+		level = l.errorLevel.Level()
+	}
+
+	ctx := context.Background()
+
+	if !l.logger.Enabled(ctx, level) {
+		return
+	}
+
+	uri := req.HTTP.URI()
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("path", string(uri.Path())),
+		slog.String("query", l.redactQuery(uri.QueryArgs())),
+		slog.Int("retry", req.Attempt),
+	}
+
+	if resp != nil {
+		attrs = append(attrs,
+			slog.Int("status", resp.StatusCode),
+			slog.Duration("latency", resp.Duration),
+		)
+	}
+
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+
+	if l.logHeaders {
+		attrs = append(attrs, slog.Any("headers", l.redactHeaders(&req.HTTP.Header)))
+	}
+
+	if l.logBody && len(req.HTTP.Body()) != 0 {
+		attrs = append(attrs, slog.String("body", l.redactBody(req.HTTP.Body())))
+	}
+
+	l.logger.LogAttrs(ctx, level, "Jira API request", attrs...)
+}
+
+// redactQuery returns query string with redacted sensitive fields
+func (l *requestLogger) redactQuery(args *fasthttp.Args) string {
+	if args.Len() == 0 {
+		return ""
+	}
+
+	query := &fasthttp.Args{}
+
+	for key, value := range args.All() {
+		if l.sensitive[strings.ToLower(string(key))] {
+			query.Add(string(key), REDACTED)
+		} else {
+			query.AddBytesKV(key, value)
+		}
+	}
+
+	return query.String()
+}
+
+// redactHeaders returns request headers with redacted credentials
+func (l *requestLogger) redactHeaders(header *fasthttp.RequestHeader) map[string]string {
+	result := map[string]string{}
+
+	for key, value := range header.All() {
+		switch strings.ToLower(string(key)) {
+		case "authorization", "cookie", "proxy-authorization":
+			result[string(key)] = REDACTED
+		default:
+			result[string(key)] = string(value)
+		}
+	}
+
+	return result
+}
+
+// redactBody returns JSON body with redacted sensitive fields
+func (l *requestLogger) redactBody(body []byte) string {
+	var data any
+
+	// We can't redact body with unknown format, so we don't log it at all
+	if json.Unmarshal(body, &data) != nil {
+		return REDACTED
+	}
+
+	result, err := json.Marshal(l.redactValue(data))
+
+	if err != nil {
+		return REDACTED
+	}
+
+	return string(result)
+}
+
+// redactValue recursively redacts sensitive fields in decoded JSON value
+func (l *requestLogger) redactValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			if l.sensitive[strings.ToLower(key)] {
+				v[key] = REDACTED
+			} else {
+				v[key] = l.redactValue(item)
+			}
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = l.redactValue(item)
+		}
+	}
+
+	return value
+}
@@ -0,0 +1,117 @@
+package jira
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"time"
+
+	"github.com/valyala/fasthttp"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Request contains info about API request
+type Request struct {
+	// Params is request parameters
+	Params Parameters
+
+	// HTTP is HTTP request which will be sent to Jira. Middleware can modify it,
+	// but it must not be used after handler returns.
+	HTTP *fasthttp.Request
+
+	// Method is HTTP method
+	Method string
+
+	// URI is request URI without Jira URL and query
+	URI string
+
+	// Attempt is attempt number (0 for the first attempt and >0 for retries)
+	Attempt int
+}
+
+// Response contains info about API response
+type Response struct {
+	// Header is response header
+	Header *fasthttp.ResponseHeader
+
+	// Body is response body
+	Body []byte
+
+	// Duration is request duration
+	Duration time.Duration
+
+	// StatusCode is HTTP status code
+	StatusCode int
+}
+
+// Handler is API request handler
+type Handler func(req *Request) (*Response, error)
+
+// Middleware is function which wraps request handler
+type Middleware func(next Handler) Handler
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Use adds middlewares to the chain. Middlewares are called in the order they
+// were added for every HTTP request sent to Jira (including retries). Child APIs
+// created by WithAuth inherit middlewares added before their creation.
+func (api *API) Use(middlewares ...Middleware) {
+	chain := make([]Middleware, 0, len(api.middlewares)+len(middlewares))
+	chain = append(chain, api.middlewares...)
+
+	for _, middleware := range middlewares {
+		if middleware != nil {
+			chain = append(chain, middleware)
+		}
+	}
+
+	api.middlewares = chain
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// getHandler returns request handler wrapped by middlewares
+func (api *API) getHandler() Handler {
+	handler := api.sendHTTPRequest
+
+	// Logger is the innermost handler, so it logs request exactly as it was sent
+	if api.logger != nil {
+		handler = api.logger.wrap(handler)
+	}
+
+	for i := len(api.middlewares) - 1; i >= 0; i-- {
+		handler = api.middlewares[i](handler)
+	}
+
+	return handler
+}
+
+// sendHTTPRequest sends HTTP request to Jira
+func (api *API) sendHTTPRequest(req *Request) (*Response, error) {
+	resp := fasthttp.AcquireResponse()
+	defer fasthttp.ReleaseResponse(resp)
+
+	start := time.Now()
+	err := api.Client.Do(req.HTTP, resp)
+	duration := time.Since(start)
+
+	if err != nil {
+		return nil, err
+	}
+
+	header := &fasthttp.ResponseHeader{}
+	resp.Header.CopyTo(header)
+
+	// Response body must be copied because response will be released
+	return &Response{
+		Header:     header,
+		Body:       append([]byte(nil), resp.Body()...),
+		Duration:   duration,
+		StatusCode: resp.StatusCode(),
+	}, nil
+}
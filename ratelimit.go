@@ -0,0 +1,212 @@
+package jira
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// DEFAULT_RATE_LIMIT_RETRIES is default number of retries for throttled requests
+const DEFAULT_RATE_LIMIT_RETRIES = 3
+
+// maxRetryAfter is maximum delay before retrying throttled request
+const maxRetryAfter = 5 * time.Minute
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// RateLimit contains client-side rate limiter configuration
+type RateLimit struct {
+	ReadRate   float64 // Maximum number of read (GET) requests per second
+	WriteRate  float64 // Maximum number of write requests per second (read limit is used if not set)
+	ReadBurst  int     // Maximum burst size for read requests (1 if not set)
+	WriteBurst int     // Maximum burst size for write requests (1 if not set)
+
+	// MaxRetries is maximum number of retries for requests throttled by Jira
+	// (429 status code), DEFAULT_RATE_LIMIT_RETRIES is used if not set. Use -1 for
+	// disabling retries.
+	MaxRetries int
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// rateLimiter is rate limiter with separate buckets for reads and writes
+type rateLimiter struct {
+	read       *tokenBucket
+	write      *tokenBucket
+	maxRetries int
+}
+
+// tokenBucket is token bucket rate limiter
+type tokenBucket struct {
+	last        time.Time
+	pausedUntil time.Time
+	rate        float64
+	burst       float64
+	tokens      float64
+
+	mu sync.Mutex
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ErrInvalidRateLimit is returned if rate limit configuration is invalid
+var ErrInvalidRateLimit = errors.New("Rate limit must be greater than zero")
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// SetRateLimit enables client-side rate limiter for all requests. Limiter is
+// shared with child APIs created by WithAuth. Limiter must be configured before
+// API is used concurrently.
+func (api *API) SetRateLimit(limit RateLimit) error {
+	if limit.ReadRate <= 0 || limit.WriteRate < 0 {
+		return ErrInvalidRateLimit
+	}
+
+	limiter := &rateLimiter{
+		read:       newTokenBucket(limit.ReadRate, limit.ReadBurst),
+		maxRetries: limit.MaxRetries,
+	}
+
+	if limit.WriteRate > 0 {
+		limiter.write = newTokenBucket(limit.WriteRate, limit.WriteBurst)
+	} else {
+		limiter.write = limiter.read
+	}
+
+	switch {
+	case limiter.maxRetries == 0:
+		limiter.maxRetries = DEFAULT_RATE_LIMIT_RETRIES
+	case limiter.maxRetries < 0:
+		limiter.maxRetries = 0
+	}
+
+	api.limiter = limiter
+
+	return nil
+}
+
+// DisableRateLimit disables client-side rate limiter. Limiter must not be
+// disabled while API is used concurrently.
+func (api *API) DisableRateLimit() {
+	api.limiter = nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// newTokenBucket creates new token bucket
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// wait waits until request with given method can be sent
+func (l *rateLimiter) wait(method string) {
+	if method == "GET" || method == "HEAD" {
+		l.read.wait()
+	} else {
+		l.write.wait()
+	}
+}
+
+// pause pauses sending requests for given duration
+func (l *rateLimiter) pause(d time.Duration) {
+	l.read.pause(d)
+
+	if l.write != l.read {
+		l.write.pause(d)
+	}
+}
+
+// wait waits for token
+func (b *tokenBucket) wait() {
+	for {
+		delay := b.take(time.Now())
+
+		if delay == 0 {
+			return
+		}
+
+		time.Sleep(delay)
+	}
+}
+
+// take takes token from bucket and returns 0, or returns delay before the
+// next attempt if there are no tokens
+func (b *tokenBucket) take(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if now.Before(b.pausedUntil) {
+		return b.pausedUntil.Sub(now)
+	}
+
+	if now.After(b.last) {
+		b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+		b.last = now
+	}
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+
+	return max(time.Duration((1-b.tokens)/b.rate*float64(time.Second)), time.Nanosecond)
+}
+
+// pause pauses bucket for given duration
+func (b *tokenBucket) pause(d time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	until := time.Now().Add(d)
+
+	if until.After(b.pausedUntil) {
+		b.pausedUntil = until
+		b.tokens = 0
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// parseRetryAfter parses Retry-After header value
+func parseRetryAfter(value string, attempt int) time.Duration {
+	value = strings.TrimSpace(value)
+
+	if value != "" {
+		seconds, err := strconv.Atoi(value)
+
+		if err == nil && seconds >= 0 {
+			return min(time.Duration(seconds)*time.Second, maxRetryAfter)
+		}
+
+		date, err := http.ParseTime(value)
+
+		if err == nil {
+			return min(max(time.Until(date), 0), maxRetryAfter)
+		}
+	}
+
+	// Use exponential backoff if server didn't send valid Retry-After header
+	return min(time.Second<<attempt, maxRetryAfter)
+}
@@ -0,0 +1,160 @@
+package jira
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// SearchIterator iterates over all issues found by search. Pagination style
+// depends on deployment type (offset for Server/Data Center and token for Cloud).
+type SearchIterator struct {
+	api    *API
+	issue  *Issue
+	err    error
+	params SearchParams
+	issues []*Issue
+	isLast bool
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// countParams is params for fetching only total number of found issues
+type countParams struct {
+	JQL        string `query:"jql"`
+	MaxResults int    `query:"maxResults,respect"`
+}
+
+// approximateCount contains approximate number of issues found by JQL query
+type approximateCount struct {
+	Count int `json:"count"`
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// SearchIter creates iterator over all issues found by JQL query
+func (api *API) SearchIter(params SearchParams) *SearchIterator {
+	return &SearchIterator{api: api, params: params}
+}
+
+// CountIssues returns number of issues found by JQL query. For Jira Cloud
+// returned number is approximate.
+// https://developer.atlassian.com/cloud/jira/platform/rest/v3/api-group-issue-search/#api-rest-api-3-search-approximate-count-post
+func (api *API) CountIssues(jql string) (int, error) {
+	if !api.isCloud {
+		return api.countIssues(jql)
+	}
+
+	result := &approximateCount{}
+	statusCode, err := api.doRequest(
+		"POST", "/rest/api/2/search/approximate-count",
+		EmptyParameters{}, result, map[string]string{"jql": jql}, true,
+	)
+
+	if err != nil {
+		return 0, err
+	}
+
+	switch statusCode {
+	case 200:
+		return result.Count, nil
+	case 400:
+		return 0, ErrInvalidInput
+	case 401:
+		return 0, ErrNoAuth
+	default:
+		return 0, makeUnknownError(statusCode)
+	}
+}
+
+// countIssues returns total number of issues found by JQL query on Jira
+// Server/Data Center
+func (api *API) countIssues(jql string) (int, error) {
+	result := &SearchResults{}
+	statusCode, err := api.doRequest(
+		"GET", "/rest/api/2/search",
+		countParams{JQL: jql}, result, nil, true,
+	)
+
+	if err != nil {
+		return 0, err
+	}
+
+	switch statusCode {
+	case 200:
+		return result.Total, nil
+	case 400:
+		return 0, ErrInvalidInput
+	case 401:
+		return 0, ErrNoAuth
+	default:
+		return 0, makeUnknownError(statusCode)
+	}
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Next fetches the next issue, it returns false if there are no more issues
+// or error occurred
+func (i *SearchIterator) Next() bool {
+	if i.err != nil {
+		return false
+	}
+
+	if len(i.issues) == 0 && !i.fetch() {
+		return false
+	}
+
+	i.issue, i.issues = i.issues[0], i.issues[1:]
+
+	return true
+}
+
+// Issue returns current issue
+func (i *SearchIterator) Issue() *Issue {
+	return i.issue
+}
+
+// Err returns search error
+func (i *SearchIterator) Err() error {
+	return i.err
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// fetch fetches next page of search results
+func (i *SearchIterator) fetch() bool {
+	if i.isLast {
+		return false
+	}
+
+	result, err := i.api.Search(i.params)
+
+	if err != nil {
+		i.err = err
+		return false
+	}
+
+	i.issues = result.Issues
+
+	if i.api.isCloud {
+		i.params.NextPageToken = result.NextPageToken
+		i.isLast = result.IsLast || result.NextPageToken == ""
+	} else {
+		i.params.StartAt += len(result.Issues)
+		i.isLast = i.params.StartAt >= result.Total
+	}
+
+	if len(i.issues) == 0 {
+		i.isLast = true
+		return false
+	}
+
+	return true
+}
+
+// ToQuery converts params to URL query
+func (p countParams) ToQuery() string {
+	return paramsToQuery(p)
+}
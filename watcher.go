@@ -0,0 +1,661 @@
+package jira
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// DEFAULT_WATCH_INTERVAL is default interval between watcher polls
+const DEFAULT_WATCH_INTERVAL = time.Minute
+
+// DEFAULT_WATCH_MAX_SNAPSHOTS is default maximum number of issue snapshots kept
+// by watcher
+const DEFAULT_WATCH_MAX_SNAPSHOTS = 10000
+
+// watcherPageSize is number of issues fetched per one search request
+const watcherPageSize = 100
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Watcher polls Jira for updated issues and emits the same events as webhooks.
+// Watcher can't detect deleted issues.
+//
+// Changelog and comment events are calculated using issue snapshots from
+// previous polls. Snapshots are kept only in memory and aren't saved to
+// checkpoint store, so after restart the first update of every issue is
+// emitted without changelog and comment events.
+type Watcher struct {
+	// API is Jira API instance
+	API *API
+
+	// Handler is handler with callbacks for emitted events
+	Handler *WebhookHandler
+
+	// Store is checkpoint store (checkpoint is kept in memory if not set)
+	Store CheckpointStore
+
+	// Location is time zone used for dates in JQL queries. It must be the same
+	// as time zone in profile of Jira user (local time zone is used if not set).
+	Location *time.Location
+
+	// OnError is called on poll errors in Run
+	OnError func(err error)
+
+	// Since is the start time for the first poll if store has no checkpoint
+	// (current time is used if not set)
+	Since time.Time
+
+	// JQL is base query for filtering issues (e.g. "project = ABC")
+	JQL string
+
+	// Fields is list of fields to fetch (all navigable fields are fetched if empty)
+	Fields []string
+
+	// Interval is interval between polls (DEFAULT_WATCH_INTERVAL is used if not set)
+	Interval time.Duration
+
+	// MaxSnapshots is maximum number of issue snapshots kept in memory, snapshots
+	// of the least recently updated issues are removed first
+	// (DEFAULT_WATCH_MAX_SNAPSHOTS is used if not set)
+	MaxSnapshots int
+
+	checkpoint *Checkpoint
+	snapshots  map[string]*Issue
+
+	mu sync.Mutex
+}
+
+// Checkpoint contains watcher state
+type Checkpoint struct {
+	Time   time.Time            `json:"time"`   // Latest processed update time
+	Issues map[string]time.Time `json:"issues"` // Update time of issues processed at the latest minute
+}
+
+// CheckpointStore is interface for watcher checkpoint storage
+type CheckpointStore interface {
+	// Load loads checkpoint, it must return nil checkpoint without error if
+	// there is no saved checkpoint
+	Load() (*Checkpoint, error)
+
+	// Save saves checkpoint
+	Save(checkpoint *Checkpoint) error
+}
+
+// MemoryCheckpointStore is in-memory checkpoint store
+type MemoryCheckpointStore struct {
+	checkpoint *Checkpoint
+	mu         sync.Mutex
+}
+
+// FileCheckpointStore is checkpoint store which saves checkpoint to JSON file
+type FileCheckpointStore struct {
+	File string
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Watcher errors
+var (
+	ErrWatcherNoAPI     = errors.New("Watcher API can't be nil")
+	ErrWatcherNoHandler = errors.New("Watcher handler can't be nil")
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Run polls Jira with configured interval until context is canceled
+func (w *Watcher) Run(ctx context.Context) error {
+	interval := w.Interval
+
+	if interval <= 0 {
+		interval = DEFAULT_WATCH_INTERVAL
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := w.Poll()
+
+		switch {
+		case errors.Is(err, ErrWatcherNoAPI), errors.Is(err, ErrWatcherNoHandler):
+			return err
+		case err != nil && w.OnError != nil:
+			w.OnError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll fetches issues updated since the latest checkpoint and emits events
+// for them
+func (w *Watcher) Poll() error {
+	switch {
+	case w.API == nil:
+		return ErrWatcherNoAPI
+	case w.Handler == nil:
+		return ErrWatcherNoHandler
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	err := w.loadCheckpoint()
+
+	if err != nil {
+		return err
+	}
+
+	// Checkpoint time is updated while processing issues, so we must use
+	// time of the previous poll for detecting created issues
+	since := w.checkpoint.Time
+
+	issues, err := w.fetchIssues()
+
+	if err != nil {
+		return err
+	}
+
+	var dispatchErr error
+
+	for _, issue := range issues {
+		if issue.Fields == nil || issue.Fields.Updated == nil || w.isProcessed(issue) {
+			continue
+		}
+
+		dispatchErr = w.emitEvents(issue, since)
+
+		if dispatchErr != nil {
+			break
+		}
+
+		w.markProcessed(issue)
+	}
+
+	w.evictSnapshots()
+
+	err = w.saveCheckpoint()
+
+	if dispatchErr != nil {
+		return dispatchErr
+	}
+
+	return err
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Load loads checkpoint from memory
+func (s *MemoryCheckpointStore) Load() (*Checkpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.checkpoint.clone(), nil
+}
+
+// Save saves checkpoint to memory
+func (s *MemoryCheckpointStore) Save(checkpoint *Checkpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.checkpoint = checkpoint.clone()
+
+	return nil
+}
+
+// Load loads checkpoint from file
+func (s FileCheckpointStore) Load() (*Checkpoint, error) {
+	data, err := os.ReadFile(s.File)
+
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	checkpoint := &Checkpoint{}
+	err = json.Unmarshal(data, checkpoint)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return checkpoint, nil
+}
+
+// Save saves checkpoint to file
+func (s FileCheckpointStore) Save(checkpoint *Checkpoint) error {
+	data, err := json.Marshal(checkpoint)
+
+	if err != nil {
+		return err
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(s.File), filepath.Base(s.File)+".*")
+
+	if err != nil {
+		return err
+	}
+
+	_, err = tmpFile.Write(data)
+
+	if err == nil {
+		err = tmpFile.Close()
+	} else {
+		tmpFile.Close()
+	}
+
+	if err == nil {
+		err = os.Rename(tmpFile.Name(), s.File)
+	}
+
+	if err != nil {
+		os.Remove(tmpFile.Name())
+	}
+
+	return err
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// loadCheckpoint loads checkpoint from store on the first poll
+func (w *Watcher) loadCheckpoint() error {
+	if w.checkpoint != nil {
+		return nil
+	}
+
+	if w.Store != nil {
+		checkpoint, err := w.Store.Load()
+
+		if err != nil {
+			return err
+		}
+
+		w.checkpoint = checkpoint
+	}
+
+	if w.checkpoint == nil {
+		w.checkpoint = &Checkpoint{Time: w.Since}
+
+		if w.checkpoint.Time.IsZero() {
+			w.checkpoint.Time = time.Now()
+		}
+	}
+
+	if w.checkpoint.Issues == nil {
+		w.checkpoint.Issues = map[string]time.Time{}
+	}
+
+	if w.snapshots == nil {
+		w.snapshots = map[string]*Issue{}
+	}
+
+	return nil
+}
+
+// saveCheckpoint removes outdated entries from checkpoint and saves it
+func (w *Watcher) saveCheckpoint() error {
+	// JQL dates have minute precision, so we must keep all issues updated
+	// within the latest minute
+	border := w.checkpoint.Time.Truncate(time.Minute)
+
+	for key, updated := range w.checkpoint.Issues {
+		if updated.Before(border) {
+			delete(w.checkpoint.Issues, key)
+		}
+	}
+
+	if w.Store == nil {
+		return nil
+	}
+
+	return w.Store.Save(w.checkpoint)
+}
+
+// fetchIssues fetches all issues updated since checkpoint
+func (w *Watcher) fetchIssues() ([]*Issue, error) {
+	var result []*Issue
+
+	it := w.API.SearchIter(SearchParams{
+		JQL:        w.getJQL(),
+		Fields:     w.getFields(),
+		MaxResults: watcherPageSize,
+	})
+
+	for it.Next() {
+		result = append(result, it.Issue())
+	}
+
+	if it.Err() != nil {
+		return nil, it.Err()
+	}
+
+	return result, nil
+}
+
+// getJQL returns JQL query for fetching updated issues
+func (w *Watcher) getJQL() string {
+	loc := w.Location
+
+	if loc == nil {
+		loc = time.Local
+	}
+
+	query := `updated >= "` + w.checkpoint.Time.In(loc).Format("2006/01/02 15:04") + `"`
+
+	if strings.TrimSpace(w.JQL) != "" {
+		query = "(" + w.JQL + ") AND " + query
+	}
+
+	return query + " ORDER BY updated ASC"
+}
+
+// getFields returns list of fields to fetch
+func (w *Watcher) getFields() []string {
+	if len(w.Fields) == 0 {
+		return nil
+	}
+
+	fields := slices.Clone(w.Fields)
+
+	for _, field := range []string{"created", "updated"} {
+		if !slices.Contains(fields, field) {
+			fields = append(fields, field)
+		}
+	}
+
+	return fields
+}
+
+// isProcessed returns true if issue update has already been processed
+func (w *Watcher) isProcessed(issue *Issue) bool {
+	updated, ok := w.checkpoint.Issues[issue.Key]
+
+	return ok && !issue.Fields.Updated.After(updated)
+}
+
+// markProcessed saves issue update into checkpoint
+func (w *Watcher) markProcessed(issue *Issue) {
+	updated := issue.Fields.Updated.Time
+
+	w.checkpoint.Issues[issue.Key] = updated
+	w.snapshots[issue.Key] = issue
+
+	if updated.After(w.checkpoint.Time) {
+		w.checkpoint.Time = updated
+	}
+}
+
+// evictSnapshots removes snapshots of the least recently updated issues if
+// number of snapshots exceeds the limit
+func (w *Watcher) evictSnapshots() {
+	maxSnapshots := w.MaxSnapshots
+
+	if maxSnapshots <= 0 {
+		maxSnapshots = DEFAULT_WATCH_MAX_SNAPSHOTS
+	}
+
+	if len(w.snapshots) <= maxSnapshots {
+		return
+	}
+
+	keys := make([]string, 0, len(w.snapshots))
+
+	for key := range w.snapshots {
+		keys = append(keys, key)
+	}
+
+	slices.SortFunc(keys, func(a, b string) int {
+		return w.snapshots[a].Fields.Updated.Compare(w.snapshots[b].Fields.Updated.Time)
+	})
+
+	for _, key := range keys[:len(keys)-maxSnapshots] {
+		delete(w.snapshots, key)
+	}
+}
+
+// emitEvents dispatches events for updated issue
+func (w *Watcher) emitEvents(issue *Issue, since time.Time) error {
+	prev := w.snapshots[issue.Key]
+	timestamp := &Timestamp{issue.Fields.Updated.Time}
+
+	if prev == nil {
+		eventType := EVENT_ISSUE_UPDATED
+
+		if issue.Fields.Created != nil && !issue.Fields.Created.Before(since.Truncate(time.Minute)) {
+			eventType = EVENT_ISSUE_CREATED
+		}
+
+		return w.Handler.Dispatch(&WebhookEvent{
+			Timestamp: timestamp,
+			Type:      eventType,
+			Issue:     issue,
+		})
+	}
+
+	err := w.Handler.Dispatch(&WebhookEvent{
+		Timestamp: timestamp,
+		Type:      EVENT_ISSUE_UPDATED,
+		Issue:     issue,
+		Changelog: &Changelog{Items: diffIssues(prev, issue)},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	for _, event := range diffComments(prev, issue) {
+		event.Timestamp = timestamp
+		err = w.Handler.Dispatch(event)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// clone creates copy of checkpoint
+func (c *Checkpoint) clone() *Checkpoint {
+	if c == nil {
+		return nil
+	}
+
+	result := &Checkpoint{Time: c.Time, Issues: map[string]time.Time{}}
+
+	for key, updated := range c.Issues {
+		result.Issues[key] = updated
+	}
+
+	return result
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// diffIssues returns list of changes between two issue snapshots
+func diffIssues(prev, cur *Issue) []*ChangelogItem {
+	var result []*ChangelogItem
+
+	p, c := prev.Fields, cur.Fields
+
+	addItem := func(field, from, to string) {
+		if from != to {
+			result = append(result, &ChangelogItem{
+				Field: field, FieldType: "jira",
+				FromString: from, ToString: to,
+			})
+		}
+	}
+
+	addItem("summary", p.Summary, c.Summary)
+	addItem("description", p.Description, c.Description)
+	addItem("environment", p.Environment, c.Environment)
+	addItem("status", nameOf(p.Status), nameOf(c.Status))
+	addItem("priority", nameOf(p.Priority), nameOf(c.Priority))
+	addItem("resolution", nameOf(p.Resolution), nameOf(c.Resolution))
+	addItem("issuetype", nameOf(p.IssueType), nameOf(c.IssueType))
+	addItem("assignee", nameOf(p.Assignee), nameOf(c.Assignee))
+	addItem("reporter", nameOf(p.Reporter), nameOf(c.Reporter))
+	addItem("duedate", formatDate(p.DueDate), formatDate(c.DueDate))
+	addItem("labels", strings.Join(p.Labels, " "), strings.Join(c.Labels, " "))
+	addItem("Component", joinComponents(p.Components), joinComponents(c.Components))
+	addItem("Fix Version", joinVersions(p.FixVersions), joinVersions(c.FixVersions))
+	addItem("Version", joinVersions(p.Versions), joinVersions(c.Versions))
+
+	var customFields []string
+
+	for name := range c.Custom {
+		customFields = append(customFields, name)
+	}
+
+	for name := range p.Custom {
+		if !c.Custom.Has(name) {
+			customFields = append(customFields, name)
+		}
+	}
+
+	slices.Sort(customFields)
+
+	for _, name := range customFields {
+		if !bytes.Equal(p.Custom[name], c.Custom[name]) {
+			result = append(result, &ChangelogItem{
+				Field: name, FieldID: name, FieldType: "custom",
+				FromString: p.Custom.Get(name), ToString: c.Custom.Get(name),
+			})
+		}
+	}
+
+	return result
+}
+
+// diffComments returns comment events for changes between two issue snapshots
+func diffComments(prev, cur *Issue) []*WebhookEvent {
+	if prev.Fields.Comments == nil || cur.Fields.Comments == nil {
+		return nil
+	}
+
+	var result []*WebhookEvent
+
+	prevComments := map[string]*Comment{}
+
+	for _, comment := range prev.Fields.Comments.Data {
+		prevComments[comment.ID] = comment
+	}
+
+	for _, comment := range cur.Fields.Comments.Data {
+		prevComment := prevComments[comment.ID]
+
+		switch {
+		case prevComment == nil:
+			result = append(result, &WebhookEvent{
+				Type: EVENT_COMMENT_CREATED, Issue: cur, Comment: comment,
+			})
+		case prevComment.Body != comment.Body:
+			result = append(result, &WebhookEvent{
+				Type: EVENT_COMMENT_UPDATED, Issue: cur, Comment: comment,
+			})
+		}
+
+		delete(prevComments, comment.ID)
+	}
+
+	for _, comment := range prev.Fields.Comments.Data {
+		if prevComments[comment.ID] != nil {
+			result = append(result, &WebhookEvent{
+				Type: EVENT_COMMENT_DELETED, Issue: cur, Comment: comment,
+			})
+		}
+	}
+
+	return result
+}
+
+// nameOf returns name of entity
+func nameOf(v any) string {
+	switch t := v.(type) {
+	case *Status:
+		if t != nil {
+			return t.Name
+		}
+	case *Priority:
+		if t != nil {
+			return t.Name
+		}
+	case *Resolution:
+		if t != nil {
+			return t.Name
+		}
+	case *IssueType:
+		if t != nil {
+			return t.Name
+		}
+	case *User:
+		if t != nil && t.Name != "" {
+			return t.Name
+		}
+
+		// Jira Cloud doesn't provide user names
+		if t != nil {
+			return t.AccountID
+		}
+	}
+
+	return ""
+}
+
+// joinComponents joins names of components
+func joinComponents(components []*Component) string {
+	var names []string
+
+	for _, component := range components {
+		names = append(names, component.Name)
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// joinVersions joins names of versions
+func joinVersions(versions []*Version) string {
+	var names []string
+
+	for _, version := range versions {
+		names = append(names, version.Name)
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// formatDate formats date
+func formatDate(d *Date) string {
+	if d == nil {
+		return ""
+	}
+
+	return d.Format("2006-01-02")
+}
@@ -0,0 +1,371 @@
+package jira
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Webhook events
+const (
+	EVENT_ISSUE_CREATED      = "jira:issue_created"
+	EVENT_ISSUE_UPDATED      = "jira:issue_updated"
+	EVENT_ISSUE_DELETED      = "jira:issue_deleted"
+	EVENT_COMMENT_CREATED    = "comment_created"
+	EVENT_COMMENT_UPDATED    = "comment_updated"
+	EVENT_COMMENT_DELETED    = "comment_deleted"
+	EVENT_WORKLOG_CREATED    = "worklog_created"
+	EVENT_WORKLOG_UPDATED    = "worklog_updated"
+	EVENT_WORKLOG_DELETED    = "worklog_deleted"
+	EVENT_SPRINT_CREATED     = "sprint_created"
+	EVENT_SPRINT_STARTED     = "sprint_started"
+	EVENT_SPRINT_CLOSED      = "sprint_closed"
+	EVENT_SPRINT_UPDATED     = "sprint_updated"
+	EVENT_SPRINT_DELETED     = "sprint_deleted"
+	EVENT_PROJECT_CREATED    = "project_created"
+	EVENT_PROJECT_UPDATED    = "project_updated"
+	EVENT_PROJECT_DELETED    = "project_deleted"
+	EVENT_USER_CREATED       = "user_created"
+	EVENT_USER_UPDATED       = "user_updated"
+	EVENT_USER_DELETED       = "user_deleted"
+	EVENT_VERSION_CREATED    = "jira:version_created"
+	EVENT_VERSION_UPDATED    = "jira:version_updated"
+	EVENT_VERSION_DELETED    = "jira:version_deleted"
+	EVENT_VERSION_RELEASED   = "jira:version_released"
+	EVENT_VERSION_UNRELEASED = "jira:version_unreleased"
+	EVENT_VERSION_MOVED      = "jira:version_moved"
+)
+
+// MAX_WEBHOOK_BODY_SIZE is default maximum size of webhook request body
+const MAX_WEBHOOK_BODY_SIZE = 10 * 1024 * 1024
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// WebhookEvent contains data from webhook delivery. Set of filled fields
+// depends on event type.
+type WebhookEvent struct {
+	Timestamp      *Timestamp `json:"timestamp"`
+	Type           string     `json:"webhookEvent"`
+	IssueEventType string     `json:"issue_event_type_name"`
+	User           *User      `json:"user"`
+	Issue          *Issue     `json:"issue"`
+	Changelog      *Changelog `json:"changelog"`
+	Comment        *Comment   `json:"comment"`
+	Worklog        *Worklog   `json:"worklog"`
+	Version        *Version   `json:"version"`
+	Project        *Project   `json:"project"`
+	Sprint         *Sprint    `json:"sprint"`
+}
+
+// Changelog contains info about changes made in issue
+type Changelog struct {
+	ID    string           `json:"id"`
+	Items []*ChangelogItem `json:"items"`
+}
+
+// ChangelogItem contains info about changed field
+type ChangelogItem struct {
+	Field      string `json:"field"`
+	FieldType  string `json:"fieldtype"`
+	FieldID    string `json:"fieldId"`
+	From       string `json:"from"`
+	FromString string `json:"fromString"`
+	To         string `json:"to"`
+	ToString   string `json:"toString"`
+}
+
+// Timestamp is Unix timestamp in milliseconds
+type Timestamp struct {
+	time.Time
+}
+
+// WebhookCallback is webhook event handler function
+type WebhookCallback func(event *WebhookEvent) error
+
+// WebhookHandler is HTTP handler for Jira webhooks
+type WebhookHandler struct {
+	// ErrorHandler is called if request can't be processed
+	ErrorHandler func(r *http.Request, err error)
+
+	// Verifier verifies deliveries authenticity
+	Verifier WebhookVerifier
+
+	// MaxBodySize is maximum size of request body (MAX_WEBHOOK_BODY_SIZE
+	// is used if not set)
+	MaxBodySize int64
+
+	// ReplayWindow is maximum difference between delivery timestamp (or JWT
+	// issue time) and current time. Deliveries processed within this window are
+	// rejected if they have been received again.
+	ReplayWindow time.Duration
+
+	callbacks    map[string][]WebhookCallback
+	anyCallbacks []WebhookCallback
+	deliveries   map[[32]byte]time.Time
+
+	mu sync.RWMutex
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Webhook errors
+var (
+	ErrWebhookBodyTooBig = errors.New("Webhook request body is too big")
+	ErrWebhookNoEvent    = errors.New("Webhook payload doesn't contain event type")
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// NewWebhookHandler creates new webhook handler
+func NewWebhookHandler() *WebhookHandler {
+	return &WebhookHandler{callbacks: map[string][]WebhookCallback{}}
+}
+
+// ParseWebhookEvent parses webhook payload
+func ParseWebhookEvent(data []byte) (*WebhookEvent, error) {
+	event := &WebhookEvent{}
+	err := json.Unmarshal(data, event)
+
+	if err != nil {
+		return nil, fmt.Errorf("Can't decode webhook payload: %w", err)
+	}
+
+	if event.Type == "" {
+		return nil, ErrWebhookNoEvent
+	}
+
+	return event, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// On registers callback for events with given type
+func (h *WebhookHandler) On(eventType string, callback WebhookCallback) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.callbacks == nil {
+		h.callbacks = map[string][]WebhookCallback{}
+	}
+
+	h.callbacks[eventType] = append(h.callbacks[eventType], callback)
+}
+
+// OnAny registers callback for all events
+func (h *WebhookHandler) OnAny(callback WebhookCallback) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.anyCallbacks = append(h.anyCallbacks, callback)
+}
+
+// Dispatch calls all callbacks registered for event type. Dispatching stops on
+// the first callback error.
+func (h *WebhookHandler) Dispatch(event *WebhookEvent) error {
+	h.mu.RLock()
+	callbacks := make([]WebhookCallback, 0, len(h.callbacks[event.Type])+len(h.anyCallbacks))
+	callbacks = append(callbacks, h.callbacks[event.Type]...)
+	callbacks = append(callbacks, h.anyCallbacks...)
+	h.mu.RUnlock()
+
+	for _, callback := range callbacks {
+		err := callback(event)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ServeHTTP parses webhook delivery and dispatches event to callbacks
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	data, err := h.readBody(r)
+
+	switch {
+	case errors.Is(err, ErrWebhookBodyTooBig):
+		h.handleError(w, r, err, http.StatusRequestEntityTooLarge)
+		return
+	case err != nil:
+		h.handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	var deliveryTime time.Time
+
+	deliveryID := sha256.Sum256(data)
+
+	switch verifier := h.Verifier.(type) {
+	case WebhookDeliveryVerifier:
+		// Body isn't signed by verifier, so only signed delivery data can be
+		// used for replay detection
+		var id string
+
+		id, deliveryTime, err = verifier.VerifyDelivery(r, data)
+		deliveryID = sha256.Sum256([]byte(id))
+	case WebhookVerifier:
+		err = verifier.Verify(r, data)
+	}
+
+	if err != nil {
+		h.handleError(w, r, err, http.StatusUnauthorized)
+		return
+	}
+
+	event, err := ParseWebhookEvent(data)
+
+	if err != nil {
+		h.handleError(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	if deliveryTime.IsZero() && event.Timestamp != nil {
+		deliveryTime = event.Timestamp.Time
+	}
+
+	if h.ReplayWindow > 0 {
+		err = h.reserveDelivery(deliveryTime, deliveryID)
+
+		if err != nil {
+			h.handleError(w, r, err, http.StatusUnauthorized)
+			return
+		}
+	}
+
+	err = h.Dispatch(event)
+
+	if err != nil {
+		// Jira retries failed deliveries, so they must not be treated as replayed
+		if h.ReplayWindow > 0 {
+			h.releaseDelivery(deliveryID)
+		}
+
+		h.handleError(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// UnmarshalJSON is a custom Timestamp format unmarshaler
+func (t *Timestamp) UnmarshalJSON(b []byte) error {
+	data := strings.Trim(string(b), "\"")
+
+	if data == "null" || data == "" {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	ms, err := strconv.ParseInt(data, 10, 64)
+
+	if err != nil {
+		return fmt.Errorf("Cannot unmarshal Timestamp value: %v", err)
+	}
+
+	t.Time = time.UnixMilli(ms)
+
+	return nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// readBody reads request body with size limit
+func (h *WebhookHandler) readBody(r *http.Request) ([]byte, error) {
+	maxSize := h.MaxBodySize
+
+	if maxSize <= 0 {
+		maxSize = MAX_WEBHOOK_BODY_SIZE
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxSize+1))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(data)) > maxSize {
+		return nil, ErrWebhookBodyTooBig
+	}
+
+	return data, nil
+}
+
+// reserveDelivery checks that delivery is inside of replay window and hasn't
+// been processed before, and atomically saves it, so concurrent identical
+// deliveries can't be processed twice
+func (h *WebhookHandler) reserveDelivery(deliveryTime time.Time, deliveryID [32]byte) error {
+	if deliveryTime.IsZero() {
+		return ErrWebhookExpired
+	}
+
+	now := time.Now()
+	diff := now.Sub(deliveryTime)
+
+	if diff > h.ReplayWindow || diff < -h.ReplayWindow {
+		return ErrWebhookExpired
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.deliveries == nil {
+		h.deliveries = map[[32]byte]time.Time{}
+	}
+
+	for id, expiry := range h.deliveries {
+		if now.After(expiry) {
+			delete(h.deliveries, id)
+		}
+	}
+
+	if _, isProcessed := h.deliveries[deliveryID]; isProcessed {
+		return ErrWebhookReplayed
+	}
+
+	// Delivery timestamp can be ahead of local time, so we keep it twice
+	// as long as replay window
+	h.deliveries[deliveryID] = now.Add(2 * h.ReplayWindow)
+
+	return nil
+}
+
+// releaseDelivery removes reserved delivery
+func (h *WebhookHandler) releaseDelivery(deliveryID [32]byte) {
+	h.mu.Lock()
+	delete(h.deliveries, deliveryID)
+	h.mu.Unlock()
+}
+
+// handleError calls error handler and writes status code
+func (h *WebhookHandler) handleError(w http.ResponseWriter, r *http.Request, err error, statusCode int) {
+	if h.ErrorHandler != nil {
+		h.ErrorHandler(r, err)
+	}
+
+	w.WriteHeader(statusCode)
+}
@@ -0,0 +1,296 @@
+package jira
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// jwtLeeway is allowed clock skew for JWT time claims
+const jwtLeeway = 30 * time.Second
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// WebhookVerifier is interface for webhook delivery authenticity verifiers
+type WebhookVerifier interface {
+	// Verify verifies webhook request with given body
+	Verify(r *http.Request, body []byte) error
+}
+
+// WebhookDeliveryVerifier is interface for webhook verifiers which sign delivery
+// metadata instead of request body. Deliveries verified by such verifiers are
+// checked for replay using signed delivery ID and issue time instead of body
+// hash and timestamp from payload.
+type WebhookDeliveryVerifier interface {
+	WebhookVerifier
+
+	// VerifyDelivery verifies webhook request with given body and returns
+	// signed delivery ID and time when delivery was issued
+	VerifyDelivery(r *http.Request, body []byte) (string, time.Time, error)
+}
+
+// HMACVerifier verifies HMAC-SHA256 signature from X-Hub-Signature header
+// (Jira Data Center 10+ and Cloud admin webhooks with secret)
+type HMACVerifier struct {
+	Secret string
+}
+
+// JWTVerifier verifies Connect-style JWT token from Authorization header
+type JWTVerifier struct {
+	Secret string // Shared secret
+	Issuer string // Expected issuer (client key), any issuer is accepted if empty
+
+	// SkipQSH disables query string hash check
+	SkipQSH bool
+
+	// AllowContextQSH allows tokens with "context-qsh" claim which isn't bound
+	// to request method, path and query
+	AllowContextQSH bool
+}
+
+// jwtClaims contains Connect JWT token claims
+type jwtClaims struct {
+	ID       string `json:"jti"`
+	Issuer   string `json:"iss"`
+	QSH      string `json:"qsh"`
+	IssuedAt int64  `json:"iat"`
+	Expiry   int64  `json:"exp"`
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Webhook verification errors
+var (
+	ErrWebhookNoSignature      = errors.New("Webhook request doesn't contain signature")
+	ErrWebhookInvalidSignature = errors.New("Webhook request signature is invalid")
+	ErrWebhookNoToken          = errors.New("Webhook request doesn't contain JWT token")
+	ErrWebhookInvalidToken     = errors.New("Webhook request JWT token is malformed")
+	ErrWebhookUnsupportedAlg   = errors.New("Webhook request JWT token signed with unsupported algorithm")
+	ErrWebhookInvalidIssuer    = errors.New("Webhook request JWT token has unexpected issuer")
+	ErrWebhookInvalidQSH       = errors.New("Webhook request JWT token query string hash doesn't match request")
+	ErrWebhookExpired          = errors.New("Webhook delivery is outside of replay window")
+	ErrWebhookReplayed         = errors.New("Webhook delivery has already been processed")
+	ErrWebhookEmptySecret      = errors.New("Webhook secret can't be empty")
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// Verify verifies X-Hub-Signature header
+func (v HMACVerifier) Verify(r *http.Request, body []byte) error {
+	if v.Secret == "" {
+		return ErrWebhookEmptySecret
+	}
+
+	signature := r.Header.Get("X-Hub-Signature")
+
+	if signature == "" {
+		return ErrWebhookNoSignature
+	}
+
+	alg, sum, ok := strings.Cut(signature, "=")
+
+	if !ok || alg != "sha256" {
+		return ErrWebhookInvalidSignature
+	}
+
+	sumData, err := hex.DecodeString(sum)
+
+	if err != nil {
+		return ErrWebhookInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(v.Secret))
+	mac.Write(body)
+
+	if !hmac.Equal(sumData, mac.Sum(nil)) {
+		return ErrWebhookInvalidSignature
+	}
+
+	return nil
+}
+
+// Verify verifies JWT token from Authorization header
+func (v JWTVerifier) Verify(r *http.Request, body []byte) error {
+	_, _, err := v.verifyToken(r)
+	return err
+}
+
+// VerifyDelivery verifies JWT token from Authorization header and returns
+// token ID (or issue time with signature if token doesn't have ID) and issue
+// time. Connect JWT doesn't sign request body, so body can't be used for
+// replay detection.
+func (v JWTVerifier) VerifyDelivery(r *http.Request, body []byte) (string, time.Time, error) {
+	claims, signature, err := v.verifyToken(r)
+
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	issuedAt := time.Unix(claims.IssuedAt, 0)
+
+	if claims.ID != "" {
+		return "jti:" + claims.ID, issuedAt, nil
+	}
+
+	return "iat:" + strconv.FormatInt(claims.IssuedAt, 10) + ":" + signature, issuedAt, nil
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// verifyToken verifies JWT token from request and returns its claims and signature
+func (v JWTVerifier) verifyToken(r *http.Request) (*jwtClaims, string, error) {
+	if v.Secret == "" {
+		return nil, "", ErrWebhookEmptySecret
+	}
+
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "JWT ")
+
+	if !ok {
+		token = r.URL.Query().Get("jwt")
+	}
+
+	if token == "" {
+		return nil, "", ErrWebhookNoToken
+	}
+
+	parts := strings.Split(token, ".")
+
+	if len(parts) != 3 {
+		return nil, "", ErrWebhookInvalidToken
+	}
+
+	header := &struct {
+		Alg string `json:"alg"`
+	}{}
+
+	err := decodeJWTPart(parts[0], header)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	if header.Alg != "HS256" {
+		return nil, "", ErrWebhookUnsupportedAlg
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+
+	if err != nil {
+		return nil, "", ErrWebhookInvalidToken
+	}
+
+	mac := hmac.New(sha256.New, []byte(v.Secret))
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, "", ErrWebhookInvalidSignature
+	}
+
+	claims := &jwtClaims{}
+
+	err = decodeJWTPart(parts[1], claims)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+
+	switch {
+	case claims.Expiry == 0,
+		now.Add(-jwtLeeway).After(time.Unix(claims.Expiry, 0)),
+		now.Add(jwtLeeway).Before(time.Unix(claims.IssuedAt, 0)):
+		return nil, "", ErrWebhookExpired
+	case v.Issuer != "" && claims.Issuer != v.Issuer:
+		return nil, "", ErrWebhookInvalidIssuer
+	case v.SkipQSH:
+		return claims, parts[2], nil
+	case claims.QSH == "context-qsh" && !v.AllowContextQSH:
+		return nil, "", ErrWebhookInvalidQSH
+	case claims.QSH != "context-qsh" && claims.QSH != getQueryStringHash(r):
+		return nil, "", ErrWebhookInvalidQSH
+	}
+
+	return claims, parts[2], nil
+}
+
+// decodeJWTPart decodes JWT header or claims
+func decodeJWTPart(data string, v any) error {
+	jsonData, err := base64.RawURLEncoding.DecodeString(data)
+
+	if err != nil {
+		return ErrWebhookInvalidToken
+	}
+
+	if json.Unmarshal(jsonData, v) != nil {
+		return ErrWebhookInvalidToken
+	}
+
+	return nil
+}
+
+// getQueryStringHash calculates Atlassian Connect query string hash for request
+func getQueryStringHash(r *http.Request) string {
+	path := strings.TrimRight(r.URL.Path, "/")
+
+	if path == "" {
+		path = "/"
+	}
+
+	query := r.URL.Query()
+	keys := make([]string, 0, len(query))
+
+	for key := range query {
+		if key != "jwt" {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	params := make([]string, 0, len(keys))
+
+	for _, key := range keys {
+		values := make([]string, 0, len(query[key]))
+
+		for _, value := range query[key] {
+			values = append(values, escapeQSH(value))
+		}
+
+		sort.Strings(values)
+		params = append(params, escapeQSH(key)+"="+strings.Join(values, ","))
+	}
+
+	canonical := strings.ToUpper(r.Method) + "&" + path + "&" + strings.Join(params, "&")
+	sum := sha256.Sum256([]byte(canonical))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// escapeQSH escapes string using RFC 3986 rules
+func escapeQSH(s string) string {
+	s = url.QueryEscape(s)
+	s = strings.ReplaceAll(s, "+", "%20")
+	s = strings.ReplaceAll(s, "*", "%2A")
+	s = strings.ReplaceAll(s, "%7E", "~")
+
+	return s
+}
@@ -0,0 +1,619 @@
+package jira
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+//                                                                                    //
+//                         Copyright (c) 2025 ESSENTIAL KAOS                          //
+//      Apache License, Version 2.0 <https://www.apache.org/licenses/LICENSE-2.0>     //
+//                                                                                    //
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// wikiListItem contains info about wiki list item
+type wikiListItem struct {
+	markers string
+	text    string
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+var (
+	wikiHeadingRegex = regexp.MustCompile(`^h([1-6])\.\s+(.*)$`)
+	wikiListRegex    = regexp.MustCompile(`^([*#]+|-)\s+(.*)$`)
+
+	mdHeadingRegex = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	mdListRegex    = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])\s+(.*)$`)
+	mdRuleRegex    = regexp.MustCompile(`^(-{3,}|\*{3,}|_{3,})$`)
+	mdTableSep     = regexp.MustCompile(`^\|?(\s*:?-+:?\s*\|)+\s*(:?-+:?)?\s*$`)
+	mdImageRegex   = regexp.MustCompile(`!\[[^\]]*\]\(([^)\s]+)[^)]*\)`)
+	mdLinkRegex    = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)[^)]*\)`)
+	mdAutoRegex    = regexp.MustCompile(`<((?:https?|mailto):[^>\s]+)>`)
+	mdBoldRegex    = regexp.MustCompile(`(\*\*|__)([^\s*_](?:.*?[^\s])?)(\*\*|__)`)
+	mdItalicRegex  = regexp.MustCompile(`(^|[^\w*])\*([^\s*](?:[^*]*?[^\s*])?)\*`)
+	mdStrikeRegex  = regexp.MustCompile(`~~([^\s~](?:.*?[^\s~])?)~~`)
+)
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// ParseWiki parses Jira wiki markup into ADF document
+func ParseWiki(text string) *ADFNode {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return NewADF(parseWikiBlocks(strings.Split(text, "\n"))...)
+}
+
+// WikiToPlainText converts Jira wiki markup to plain text
+func WikiToPlainText(text string) string {
+	return ParseWiki(text).PlainText()
+}
+
+// WikiToMarkdown converts Jira wiki markup to Markdown
+func WikiToMarkdown(text string) string {
+	return ParseWiki(text).Markdown()
+}
+
+// WikiToHTML converts Jira wiki markup to HTML
+func WikiToHTML(text string) string {
+	return ParseWiki(text).HTML()
+}
+
+// MarkdownToWiki converts Markdown to Jira wiki markup
+func MarkdownToWiki(text string) string {
+	var result []string
+
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(trimmed, "```"):
+			lang := strings.TrimSpace(strings.TrimPrefix(trimmed, "```"))
+
+			if lang == "" {
+				result = append(result, "{code}")
+			} else {
+				result = append(result, "{code:"+lang+"}")
+			}
+
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+				result = append(result, lines[i])
+			}
+
+			result = append(result, "{code}")
+
+		case strings.HasPrefix(trimmed, ">"):
+			var quote []string
+
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">"); i++ {
+				line := strings.TrimPrefix(strings.TrimSpace(lines[i]), ">")
+				quote = append(quote, strings.TrimPrefix(line, " "))
+			}
+
+			i--
+
+			result = append(result, "{quote}", MarkdownToWiki(strings.Join(quote, "\n")), "{quote}")
+
+		case mdRuleRegex.MatchString(trimmed):
+			result = append(result, "----")
+
+		case mdHeadingRegex.MatchString(trimmed):
+			match := mdHeadingRegex.FindStringSubmatch(trimmed)
+			result = append(result, "h"+strconv.Itoa(len(match[1]))+". "+convertMarkdownInline(match[2]))
+
+		case mdListRegex.MatchString(line):
+			var markers []string
+
+			for ; i < len(lines) && mdListRegex.MatchString(lines[i]); i++ {
+				match := mdListRegex.FindStringSubmatch(lines[i])
+				level := len(strings.ReplaceAll(match[1], "\t", "  "))/2 + 1
+				marker := "*"
+
+				if unicode.IsDigit(rune(match[2][0])) {
+					marker = "#"
+				}
+
+				// Keep parent markers, so mixed lists are converted properly
+				markers = append(markers[:min(level-1, len(markers))], marker)
+
+				result = append(result, strings.Join(markers, "")+" "+convertMarkdownInline(match[3]))
+			}
+
+			i--
+
+		case strings.HasPrefix(trimmed, "|") && i+1 < len(lines) && mdTableSep.MatchString(strings.TrimSpace(lines[i+1])):
+			cells := splitMarkdownRow(trimmed)
+			result = append(result, "||"+strings.Join(cells, "||")+"||")
+
+			for i += 2; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), "|"); i++ {
+				cells = splitMarkdownRow(strings.TrimSpace(lines[i]))
+				result = append(result, "|"+strings.Join(cells, "|")+"|")
+			}
+
+			i--
+
+		default:
+			result = append(result, convertMarkdownInline(line))
+		}
+	}
+
+	return strings.Join(result, "\n")
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// parseWikiBlocks parses block elements
+func parseWikiBlocks(lines []string) []*ADFNode {
+	var result, paragraph []*ADFNode
+
+	flushParagraph := func() {
+		if len(paragraph) != 0 {
+			result = append(result, ADFParagraph(paragraph...))
+			paragraph = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+
+		if line == "" {
+			flushParagraph()
+			continue
+		}
+
+		if macro, params, ok := parseWikiMacro(line); ok {
+			var body []string
+
+			body, i = collectWikiMacro(lines, i, macro)
+
+			flushParagraph()
+
+			switch macro {
+			case "code":
+				result = append(result, ADFCodeBlock(getWikiCodeLanguage(params), strings.Join(body, "\n")))
+			case "noformat":
+				result = append(result, ADFCodeBlock("", strings.Join(body, "\n")))
+			case "quote":
+				result = append(result, ADFBlockquote(parseWikiBlocks(body)...))
+			case "panel":
+				result = append(result, &ADFNode{Type: ADF_PANEL, Content: parseWikiBlocks(body)})
+			}
+
+			continue
+		}
+
+		switch {
+		case wikiHeadingRegex.MatchString(line):
+			flushParagraph()
+			match := wikiHeadingRegex.FindStringSubmatch(line)
+			level, _ := strconv.Atoi(match[1])
+			result = append(result, ADFHeading(level, parseWikiInline(match[2])...))
+
+		case strings.HasPrefix(line, "bq. "):
+			flushParagraph()
+			result = append(result, ADFBlockquote(ADFParagraph(parseWikiInline(line[4:])...)))
+
+		case line == "----":
+			flushParagraph()
+			result = append(result, ADFRule())
+
+		case wikiListRegex.MatchString(line):
+			flushParagraph()
+
+			var items []*wikiListItem
+
+			for ; i < len(lines) && wikiListRegex.MatchString(strings.TrimSpace(lines[i])); i++ {
+				match := wikiListRegex.FindStringSubmatch(strings.TrimSpace(lines[i]))
+				items = append(items, &wikiListItem{match[1], match[2]})
+			}
+
+			i--
+
+			result = append(result, buildWikiLists(items, 0)...)
+
+		case strings.HasPrefix(line, "|"):
+			flushParagraph()
+
+			table := ADFTable()
+
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), "|"); i++ {
+				table.Append(parseWikiTableRow(strings.TrimSpace(lines[i])))
+			}
+
+			i--
+
+			result = append(result, table)
+
+		default:
+			// Line breaks inside of paragraph are preserved by Jira
+			if len(paragraph) != 0 {
+				paragraph = append(paragraph, ADFHardBreak())
+			}
+
+			paragraph = append(paragraph, parseWikiInline(line)...)
+		}
+	}
+
+	flushParagraph()
+
+	return result
+}
+
+// parseWikiMacro parses block macro tag ({code}, {noformat}, {quote}, {panel})
+func parseWikiMacro(line string) (string, string, bool) {
+	if !strings.HasPrefix(line, "{") {
+		return "", "", false
+	}
+
+	end := strings.IndexByte(line, '}')
+
+	if end == -1 {
+		return "", "", false
+	}
+
+	macro, params, _ := strings.Cut(line[1:end], ":")
+
+	switch macro {
+	case "code", "noformat", "quote", "panel":
+		return macro, params, true
+	}
+
+	return "", "", false
+}
+
+// collectWikiMacro returns macro body and index of the last line of macro
+func collectWikiMacro(lines []string, index int, macro string) ([]string, int) {
+	var body []string
+
+	tag := "{" + macro + "}"
+	line := strings.TrimSpace(lines[index])
+	line = line[strings.IndexByte(line, '}')+1:]
+
+	for {
+		if before, _, found := strings.Cut(line, tag); found {
+			if before != "" {
+				body = append(body, before)
+			}
+
+			return body, index
+		}
+
+		if line != "" || len(body) != 0 {
+			body = append(body, line)
+		}
+
+		index++
+
+		if index >= len(lines) {
+			return body, index
+		}
+
+		line = lines[index]
+	}
+}
+
+// getWikiCodeLanguage extracts language from code macro parameters
+func getWikiCodeLanguage(params string) string {
+	for _, param := range strings.Split(params, "|") {
+		key, value, found := strings.Cut(param, "=")
+
+		switch {
+		case !found:
+			return strings.TrimSpace(key)
+		case strings.TrimSpace(key) == "language":
+			return strings.TrimSpace(value)
+		}
+	}
+
+	return ""
+}
+
+// buildWikiLists builds lists from list items
+func buildWikiLists(items []*wikiListItem, depth int) []*ADFNode {
+	var lists []*ADFNode
+	var list, listItem *ADFNode
+
+	for i := 0; i < len(items); {
+		listType := getWikiListType(items[i].markers[depth])
+
+		if list == nil || (len(items[i].markers) == depth+1 && list.Type != listType) {
+			list = &ADFNode{Type: listType}
+			listItem = nil
+			lists = append(lists, list)
+		}
+
+		if len(items[i].markers) == depth+1 {
+			listItem = ADFListItem(ADFParagraph(parseWikiInline(items[i].text)...))
+			list.Append(listItem)
+			i++
+			continue
+		}
+
+		j := i
+
+		for j < len(items) && len(items[j].markers) > depth+1 {
+			j++
+		}
+
+		if listItem == nil {
+			listItem = ADFListItem()
+			list.Append(listItem)
+		}
+
+		listItem.Append(buildWikiLists(items[i:j], depth+1)...)
+		i = j
+	}
+
+	return lists
+}
+
+// getWikiListType returns list type for given marker
+func getWikiListType(marker byte) string {
+	if marker == '#' {
+		return ADF_ORDERED_LIST
+	}
+
+	return ADF_BULLET_LIST
+}
+
+// parseWikiTableRow parses table row
+func parseWikiTableRow(line string) *ADFNode {
+	row := ADFTableRow()
+	line = strings.TrimSuffix(strings.TrimSuffix(line, "|"), "|")
+
+	for len(line) != 0 {
+		isHeader := strings.HasPrefix(line, "||")
+		line = strings.TrimPrefix(line[1:], "|")
+
+		end := indexWikiCellEnd(line)
+		content := ADFParagraph(parseWikiInline(strings.TrimSpace(line[:end]))...)
+
+		if isHeader {
+			row.Append(ADFTableHeader(content))
+		} else {
+			row.Append(ADFTableCell(content))
+		}
+
+		line = line[end:]
+	}
+
+	return row
+}
+
+// indexWikiCellEnd returns index of the end of table cell, pipes inside of links
+// and macros are ignored
+func indexWikiCellEnd(line string) int {
+	var depth int
+
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '[', '{':
+			depth++
+		case ']', '}':
+			depth = max(depth-1, 0)
+		case '|':
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return len(line)
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// parseWikiInline parses inline elements
+func parseWikiInline(text string) []*ADFNode {
+	var result []*ADFNode
+	var buf strings.Builder
+
+	flushText := func() {
+		if buf.Len() != 0 {
+			result = append(result, ADFText(buf.String()))
+			buf.Reset()
+		}
+	}
+
+	for i := 0; i < len(text); i++ {
+		char := text[i]
+
+		switch {
+		case char == '\\' && strings.HasPrefix(text[i:], `\\`):
+			flushText()
+			result = append(result, ADFHardBreak())
+			i++
+			continue
+
+		case char == '\\' && i+1 < len(text):
+			buf.WriteByte(text[i+1])
+			i++
+			continue
+
+		case char == '{' && strings.HasPrefix(text[i:], "{{"):
+			if end := strings.Index(text[i+2:], "}}"); end != -1 {
+				flushText()
+				result = append(result, ADFText(text[i+2:i+2+end]).Code())
+				i += end + 3
+				continue
+			}
+
+		case char == '[':
+			if end := strings.IndexByte(text[i:], ']'); end != -1 {
+				flushText()
+				result = append(result, parseWikiLink(text[i+1:i+end])...)
+				i += end
+				continue
+			}
+
+		case char == '!':
+			if end := strings.IndexByte(text[i+1:], '!'); end > 0 && isWikiImage(text[i+1:i+1+end]) {
+				flushText()
+				url, _, _ := strings.Cut(text[i+1:i+1+end], "|")
+				result = append(result, &ADFNode{
+					Type:  ADF_MEDIA,
+					Attrs: map[string]any{"type": "external", "url": url},
+				})
+				i += end + 1
+				continue
+			}
+
+		case strings.IndexByte("*_-+", char) != -1:
+			if end := findWikiMarkEnd(text, i); end != -1 {
+				flushText()
+
+				for _, node := range parseWikiInline(text[i+1 : end]) {
+					if node.Type == ADF_TEXT {
+						node.addMark(&ADFMark{Type: getWikiMarkType(char)})
+					}
+
+					result = append(result, node)
+				}
+
+				i = end
+				continue
+			}
+		}
+
+		buf.WriteByte(char)
+	}
+
+	flushText()
+
+	return result
+}
+
+// parseWikiLink parses link, mention or anchor
+func parseWikiLink(text string) []*ADFNode {
+	title, url, hasTitle := strings.Cut(text, "|")
+
+	if !hasTitle {
+		url = title
+	}
+
+	if strings.HasPrefix(url, "~") {
+		return []*ADFNode{ADFMention(url[1:], url[1:])}
+	}
+
+	var result []*ADFNode
+
+	if hasTitle {
+		result = parseWikiInline(title)
+	} else {
+		result = []*ADFNode{ADFText(strings.TrimPrefix(url, "mailto:"))}
+	}
+
+	for _, node := range result {
+		if node.Type == ADF_TEXT {
+			node.Link(url)
+		}
+	}
+
+	return result
+}
+
+// findWikiMarkEnd returns index of closing text effect marker or -1 if text
+// at given position is not text effect
+func findWikiMarkEnd(text string, start int) int {
+	char := text[start]
+
+	// Opening marker must be at the start of the word and must be followed
+	// by non-space symbol
+	if start > 0 && isWikiWordChar(text[start-1]) {
+		return -1
+	}
+
+	if start+1 >= len(text) || text[start+1] == ' ' || text[start+1] == char {
+		return -1
+	}
+
+	for i := start + 2; i < len(text); i++ {
+		if text[i] != char || text[i-1] == ' ' {
+			continue
+		}
+
+		if i+1 == len(text) || !isWikiWordChar(text[i+1]) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// getWikiMarkType returns ADF mark type for given wiki marker
+func getWikiMarkType(char byte) string {
+	switch char {
+	case '*':
+		return ADF_MARK_STRONG
+	case '_':
+		return ADF_MARK_EM
+	case '-':
+		return ADF_MARK_STRIKE
+	}
+
+	return ADF_MARK_UNDERLINE
+}
+
+// isWikiWordChar returns true if given symbol is a part of word
+func isWikiWordChar(char byte) bool {
+	return char >= 'a' && char <= 'z' || char >= 'A' && char <= 'Z' ||
+		char >= '0' && char <= '9' || char >= 0x80
+}
+
+// isWikiImage returns true if text between exclamation marks is an image
+func isWikiImage(text string) bool {
+	return text != "" && text[0] != ' ' && text[len(text)-1] != ' ' &&
+		!strings.ContainsAny(text, "\n")
+}
+
+// ////////////////////////////////////////////////////////////////////////////////// //
+
+// convertMarkdownInline converts Markdown inline elements to wiki markup
+func convertMarkdownInline(text string) string {
+	var result strings.Builder
+
+	chunks := []string{text}
+
+	// Code spans must be converted as is
+	if strings.Count(text, "`")%2 == 0 {
+		chunks = strings.Split(text, "`")
+	}
+
+	for i, chunk := range chunks {
+		if i%2 == 1 {
+			result.WriteString("{{" + chunk + "}}")
+			continue
+		}
+
+		chunk = mdImageRegex.ReplaceAllString(chunk, "!$1!")
+		chunk = mdLinkRegex.ReplaceAllString(chunk, "[$1|$2]")
+		chunk = mdAutoRegex.ReplaceAllString(chunk, "[$1]")
+		chunk = mdBoldRegex.ReplaceAllString(chunk, "\x00$2\x00")
+		chunk = mdItalicRegex.ReplaceAllString(chunk, "${1}_${2}_")
+		chunk = mdStrikeRegex.ReplaceAllString(chunk, "-$1-")
+		chunk = strings.ReplaceAll(chunk, "\x00", "*")
+
+		result.WriteString(chunk)
+	}
+
+	return result.String()
+}
+
+// splitMarkdownRow splits Markdown table row to cells
+func splitMarkdownRow(line string) []string {
+	line = strings.TrimSuffix(strings.TrimPrefix(line, "|"), "|")
+	cells := strings.Split(line, "|")
+
+	for i, cell := range cells {
+		cells[i] = convertMarkdownInline(strings.TrimSpace(cell))
+	}
+
+	return cells
+}